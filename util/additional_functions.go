@@ -2,7 +2,10 @@
 // It complements Go's standard library slices package with additional functionality.
 package util
 
-import "slices"
+import (
+	"slices"
+	"time"
+)
 
 // Contains checks if a slice contains a specific element.
 // It returns true if the element is found, false otherwise.
@@ -33,6 +36,47 @@ func LastIndexOf[S ~[]E, E comparable](collection S, element E) int {
 	return -1
 }
 
+// IndicesOf returns every index at which element occurs in a slice, in ascending order.
+// It returns an empty (non-nil) slice if the element is not found and nil for nil input.
+func IndicesOf[S ~[]E, E comparable](collection S, element E) []int {
+	return IndicesWhere(collection, func(item E, _ int) bool { return item == element })
+}
+
+// IndicesWhere returns every index whose element satisfies a predicate function, in
+// ascending order. It returns an empty (non-nil) slice if no element matches and nil
+// for nil input.
+func IndicesWhere[S ~[]E, E any](collection S, predicate func(item E, index int) bool) []int {
+	if collection == nil {
+		return nil
+	}
+
+	result := []int{}
+	for i, item := range collection {
+		if predicate(item, i) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// ChangePoints returns every index i >= 1 at which the element differs from the one before
+// it, in ascending order, which marks where each new run of equal values begins. It
+// returns an empty (non-nil) slice if there are no changes, including for slices with
+// fewer than two elements, and nil for nil input.
+func ChangePoints[S ~[]E, E comparable](collection S) []int {
+	if collection == nil {
+		return nil
+	}
+
+	result := []int{}
+	for i := 1; i < len(collection); i++ {
+		if collection[i] != collection[i-1] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
 // Difference returns a new slice containing elements that are in the first slice
 // but not in any of the other slices.
 func Difference[S ~[]E, E comparable](first S, others ...S) S {
@@ -57,6 +101,35 @@ func Difference[S ~[]E, E comparable](first S, others ...S) S {
 	return result
 }
 
+// DifferenceMultiset returns a new slice containing the elements of first after removing,
+// for each value, as many copies as appear across all of the other slices combined.
+// Unlike Difference, which removes every occurrence of a value found in the others, it
+// respects multiplicities: [1, 1, 1, 2] minus [1] yields [1, 1, 2]. Copies are removed
+// from the front of first, and the remaining elements keep their order.
+// It returns nil if first is nil.
+func DifferenceMultiset[S ~[]E, E comparable](first S, others ...S) S {
+	if first == nil {
+		return nil
+	}
+
+	remove := make(map[E]int)
+	for _, other := range others {
+		for _, item := range other {
+			remove[item]++
+		}
+	}
+
+	result := make(S, 0, len(first))
+	for _, item := range first {
+		if remove[item] > 0 {
+			remove[item]--
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 // Union returns a new slice containing unique elements from all provided slices.
 // The order of elements is preserved based on their first occurrence across all slices.
 func Union[S ~[]E, E comparable](slices ...S) S {
@@ -86,6 +159,76 @@ func ForEach[S ~[]E, E any](collection S, action func(item E, index int)) {
 	}
 }
 
+// ForEachStride executes a provided function for every stride-th slice element, that is
+// for indices 0, stride, 2*stride, and so on. A stride less than 1 is treated as 1, which
+// visits every element.
+func ForEachStride[S ~[]E, E any](collection S, stride int, action func(item E, index int)) {
+	stride = max(stride, 1)
+	for i := 0; i < len(collection); i += stride {
+		action(collection[i], i)
+	}
+}
+
+// ForEachRetry executes action once for each slice element, retrying an element up to
+// attempts times in total while action returns an error. If an element still fails after
+// its last attempt, iteration stops and that attempt's error is returned unchanged.
+// An attempts value less than 1 is treated as 1. It returns nil for nil or empty input.
+func ForEachRetry[S ~[]E, E any](collection S, attempts int, action func(item E, index int) error) error {
+	attempts = max(attempts, 1)
+	for i, item := range collection {
+		var err error
+		for range attempts {
+			if err = action(item, i); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEachTimed executes action once for each slice element and returns how long each call
+// took, indexed like the collection, which helps find slow elements without an external
+// profiler. Durations are measured with the monotonic clock and include only the call to
+// action. It returns nil for nil input and an empty (non-nil) slice for empty input.
+func ForEachTimed[S ~[]E, E any](collection S, action func(item E, index int)) []time.Duration {
+	if collection == nil {
+		return nil
+	}
+
+	durations := make([]time.Duration, len(collection))
+	for i, item := range collection {
+		start := time.Now()
+		action(item, i)
+		durations[i] = time.Since(start)
+	}
+	return durations
+}
+
+// EachChunkIndexed splits a slice into chunks of the given size, as Chunk does, and calls
+// fn with each chunk and its 0-based chunk index, which is useful for progress reporting
+// and resumable processing. Iteration stops at the first error, which is returned
+// unchanged. Each chunk is a view into collection. It returns nil for nil input and
+// ErrInvalidSize if size is less than 1.
+func EachChunkIndexed[S ~[]E, E any](collection S, size int, fn func(chunkIndex int, chunk S) error) error {
+	if collection == nil {
+		return nil
+	}
+	if size < 1 {
+		return ErrInvalidSize
+	}
+
+	for chunkIndex, start := 0, 0; start < len(collection); chunkIndex, start = chunkIndex+1, start+size {
+		end := min(start+size, len(collection))
+		if err := fn(chunkIndex, collection[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Reverse returns a new slice with the elements in reverse order.
 //
 // Note: For Go 1.21+, consider using slices.Clone and slices.Reverse from the standard library.
@@ -105,6 +248,15 @@ func Reverse[S ~[]E, E any](collection S) S {
 	return result
 }
 
+// ReverseInPlace reverses the order of the elements of the caller's slice without allocating.
+//
+// Unlike Reverse, this intentionally mutates its input; it exists for tight loops where
+// the clone returned by Reverse adds unnecessary GC pressure. Nil, empty and single-element
+// slices are left untouched.
+func ReverseInPlace[S ~[]E, E any](collection S) {
+	slices.Reverse(collection)
+}
+
 // Take returns a new slice containing the first n elements of the original slice.
 // If n is greater than the length of the slice, the entire slice is returned.
 func Take[S ~[]E, E any](collection S, n int) S {
@@ -142,3 +294,232 @@ func Drop[S ~[]E, E any](collection S, n int) S {
 
 	return slices.Clone(collection[n:])
 }
+
+// TakeUntil returns a new slice containing the elements before the first one for which
+// the predicate returns true. If no element matches, a clone of the whole slice is
+// returned; if the first element matches, the result is empty (non-nil).
+// It returns nil for nil input.
+func TakeUntil[S ~[]E, E any](collection S, predicate func(item E, index int) bool) S {
+	if collection == nil {
+		return nil
+	}
+
+	for i, item := range collection {
+		if predicate(item, i) {
+			return append(S{}, collection[:i]...)
+		}
+	}
+	return append(S{}, collection...)
+}
+
+// DropUntil returns a new slice starting at the first element for which the predicate
+// returns true, so it is the complement of TakeUntil. If no element matches, an empty
+// (non-nil) slice is returned. It returns nil for nil input.
+func DropUntil[S ~[]E, E any](collection S, predicate func(item E, index int) bool) S {
+	if collection == nil {
+		return nil
+	}
+
+	for i, item := range collection {
+		if predicate(item, i) {
+			return append(S{}, collection[i:]...)
+		}
+	}
+	return S{}
+}
+
+// FirstN returns a new slice containing up to the first n elements of the original slice.
+// It is a friendlier name for Take: it returns an empty (non-nil) slice when n <= 0,
+// a clone of the whole slice when n exceeds its length, and nil for nil input.
+func FirstN[S ~[]E, E any](collection S, n int) S {
+	return Take(collection, n)
+}
+
+// LastN returns a new slice containing up to the last n elements of the original slice.
+// It returns an empty (non-nil) slice when n <= 0, a clone of the whole slice when n
+// exceeds its length, and nil for nil input.
+func LastN[S ~[]E, E any](collection S, n int) S {
+	if collection == nil {
+		return nil
+	}
+
+	if n <= 0 {
+		return S{}
+	}
+
+	length := len(collection)
+	if n >= length {
+		return slices.Clone(collection)
+	}
+
+	return slices.Clone(collection[length-n:])
+}
+
+// GetOr returns the element at the given index, or fallback if the index is out of range.
+// Negative indices count from the end of the slice, so -1 refers to the last element.
+// It never panics, regardless of the index or whether the slice is nil.
+func GetOr[S ~[]E, E any](collection S, index int, fallback E) E {
+	length := len(collection)
+	if index < 0 {
+		index += length
+	}
+
+	if index < 0 || index >= length {
+		return fallback
+	}
+	return collection[index]
+}
+
+// SetEqual reports whether two slices contain the same set of distinct values,
+// ignoring both order and how many times each value occurs. For example, [1, 1, 2]
+// and [2, 1] are set-equal even though they are not equal as multisets, where the
+// count of each value would also have to match.
+// Nil and empty slices are considered equal to each other.
+func SetEqual[S ~[]E, E comparable](a, b S) bool {
+	setA := make(map[E]struct{}, len(a))
+	for _, item := range a {
+		setA[item] = struct{}{}
+	}
+
+	setB := make(map[E]struct{}, len(b))
+	for _, item := range b {
+		if _, found := setA[item]; !found {
+			return false
+		}
+		setB[item] = struct{}{}
+	}
+
+	return len(setA) == len(setB)
+}
+
+// RotateTo returns a new slice rotated so that the first occurrence of value is at index 0,
+// preserving the cyclic order of the elements. If value is not present, it returns an
+// unchanged clone. It returns nil for nil input.
+func RotateTo[S ~[]E, E comparable](collection S, value E) S {
+	if collection == nil {
+		return nil
+	}
+
+	position := slices.Index(collection, value)
+	if position <= 0 {
+		return slices.Clone(collection)
+	}
+
+	result := make(S, 0, len(collection))
+	result = append(result, collection[position:]...)
+	result = append(result, collection[:position]...)
+	return result
+}
+
+// Swap returns a new slice with the elements at indices i and j exchanged, leaving the
+// input untouched. If either index is out of range, the swap is a no-op and an unchanged
+// clone is returned instead of panicking. It returns nil for nil input.
+func Swap[S ~[]E, E any](collection S, i, j int) S {
+	if collection == nil {
+		return nil
+	}
+
+	result := slices.Clone(collection)
+	length := len(result)
+	if i < 0 || i >= length || j < 0 || j >= length {
+		return result
+	}
+
+	result[i], result[j] = result[j], result[i]
+	return result
+}
+
+// Move returns a new slice with the element at index from relocated to index to, shifting
+// the elements in between by one position. The input is not modified.
+// Both indices are clamped into [0, len-1] before moving, so a negative index refers to the
+// first element and an index past the end refers to the last. It returns nil for nil input
+// and an empty (non-nil) slice for empty input.
+func Move[S ~[]E, E any](collection S, from, to int) S {
+	if collection == nil {
+		return nil
+	}
+
+	result := slices.Clone(collection)
+	if len(result) == 0 {
+		return result
+	}
+
+	last := len(result) - 1
+	from = min(max(from, 0), last)
+	to = min(max(to, 0), last)
+
+	item := result[from]
+	if from < to {
+		copy(result[from:to], result[from+1:to+1])
+	} else {
+		copy(result[to+1:from+1], result[to:from])
+	}
+	result[to] = item
+	return result
+}
+
+// Intersperse returns a new slice with sep inserted between every pair of adjacent
+// elements, but not before the first or after the last element. A slice with fewer
+// than two elements is returned as an unchanged clone. It returns nil for nil input.
+func Intersperse[S ~[]E, E any](collection S, sep E) S {
+	if collection == nil {
+		return nil
+	}
+
+	length := len(collection)
+	if length < 2 {
+		return slices.Clone(collection)
+	}
+
+	result := make(S, 0, 2*length-1)
+	for i, item := range collection {
+		if i > 0 {
+			result = append(result, sep)
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// CoalesceAdjacent returns a new slice in which runs of adjacent mergeable elements are
+// folded into a single element. Elements are visited in order: canMerge is called with
+// the element accumulated so far and the next element, and when it returns true the two
+// are replaced by merge(accumulated, next). This generalizes run-length-style compaction,
+// for example coalescing overlapping intervals in a sorted slice.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func CoalesceAdjacent[S ~[]E, E any](collection S, canMerge func(a, b E) bool, merge func(a, b E) E) S {
+	if collection == nil {
+		return nil
+	}
+	if len(collection) == 0 {
+		return S{}
+	}
+
+	result := make(S, 0, len(collection))
+	current := collection[0]
+	for _, item := range collection[1:] {
+		if canMerge(current, item) {
+			current = merge(current, item)
+			continue
+		}
+		result = append(result, current)
+		current = item
+	}
+	return append(result, current)
+}
+
+// Cycle returns a new slice of the given length formed by repeating collection from the
+// start, wrapping around as often as needed; a length shorter than collection truncates
+// it. Since there is nothing to repeat, it returns an empty (non-nil) slice for nil or
+// empty input regardless of length, and also when length <= 0.
+func Cycle[S ~[]E, E any](collection S, length int) S {
+	if len(collection) == 0 || length <= 0 {
+		return S{}
+	}
+
+	result := make(S, length)
+	for filled := 0; filled < length; {
+		filled += copy(result[filled:], collection)
+	}
+	return result
+}
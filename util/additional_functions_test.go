@@ -1,8 +1,11 @@
 package util
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestContains(t *testing.T) {
@@ -127,6 +130,79 @@ func TestLastIndexOf(t *testing.T) {
 	})
 }
 
+func TestIndicesOf(t *testing.T) {
+	t.Run("returns all indices of multiple occurrences", func(t *testing.T) {
+		input := []int{1, 2, 3, 2, 1, 2}
+		expected := []int{1, 3, 5}
+		result := IndicesOf(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IndicesOf() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when element is absent", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{}
+		result := IndicesOf(input, 4)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IndicesOf() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		var input []int
+		result := IndicesOf(input, 1)
+		if result != nil {
+			t.Errorf("IndicesOf() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestIndicesWhere(t *testing.T) {
+	t.Run("returns indices of elements matching the predicate", func(t *testing.T) {
+		input := []string{"apple", "kiwi", "avocado", "banana"}
+		expected := []int{0, 2}
+		result := IndicesWhere(input, func(item string, _ int) bool { return item[0] == 'a' })
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IndicesWhere() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when nothing matches", func(t *testing.T) {
+		input := []int{1, 3, 5}
+		expected := []int{}
+		result := IndicesWhere(input, func(item int, _ int) bool { return item%2 == 0 })
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IndicesWhere() got = %v, want %v", result, expected)
+		}
+	})
+}
+
+func TestChangePoints(t *testing.T) {
+	t.Run("returns indices where the value changes", func(t *testing.T) {
+		expected := []int{2, 3, 6}
+		result := ChangePoints([]string{"ok", "ok", "warn", "ok", "ok", "ok", "down"})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ChangePoints() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when nothing changes", func(t *testing.T) {
+		for _, input := range [][]int{{4, 4, 4}, {7}, {}} {
+			result := ChangePoints(input)
+			if result == nil || len(result) != 0 {
+				t.Errorf("ChangePoints(%v) should return empty slice, but got %v", input, result)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := ChangePoints([]int(nil)); result != nil {
+			t.Errorf("ChangePoints() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestDifference(t *testing.T) {
 	t.Run("returns elements in first slice but not in second", func(t *testing.T) {
 		first := []int{1, 2, 3, 4, 5}
@@ -178,6 +254,40 @@ func TestDifference(t *testing.T) {
 	})
 }
 
+func TestDifferenceMultiset(t *testing.T) {
+	t.Run("removes only as many copies as the others contain", func(t *testing.T) {
+		expected := []int{1, 1, 2}
+		result := DifferenceMultiset([]int{1, 1, 1, 2}, []int{1})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("DifferenceMultiset() got = %v, want %v", result, expected)
+		}
+		if set := Difference([]int{1, 1, 1, 2}, []int{1}); !reflect.DeepEqual(set, []int{2}) {
+			t.Errorf("Difference() got = %v, want %v", set, []int{2})
+		}
+	})
+
+	t.Run("combines counts across the other slices", func(t *testing.T) {
+		expected := []string{"a", "c", "b"}
+		result := DifferenceMultiset([]string{"a", "b", "a", "a", "c", "b"}, []string{"a", "b"}, []string{"a", "x"})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("DifferenceMultiset() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when everything is removed", func(t *testing.T) {
+		result := DifferenceMultiset([]int{1, 2}, []int{2, 1, 1})
+		if result == nil || len(result) != 0 {
+			t.Errorf("DifferenceMultiset() should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil first slice", func(t *testing.T) {
+		if result := DifferenceMultiset(nil, []int{1}); result != nil {
+			t.Errorf("DifferenceMultiset() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestUnion(t *testing.T) {
 	t.Run("combines unique elements from all slices", func(t *testing.T) {
 		slice1 := []int{1, 2, 3}
@@ -275,6 +385,218 @@ func TestForEach(t *testing.T) {
 	})
 }
 
+func TestForEachStride(t *testing.T) {
+	t.Run("invokes action on every stride-th index", func(t *testing.T) {
+		input := make([]int, 10)
+		var indices []int
+		ForEachStride(input, 3, func(_ int, index int) {
+			indices = append(indices, index)
+		})
+		expected := []int{0, 3, 6, 9}
+		if !reflect.DeepEqual(indices, expected) {
+			t.Errorf("ForEachStride() indices got = %v, want %v", indices, expected)
+		}
+	})
+
+	t.Run("passes the element at each index", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d", "e"}
+		var items []string
+		ForEachStride(input, 2, func(item string, _ int) {
+			items = append(items, item)
+		})
+		expected := []string{"a", "c", "e"}
+		if !reflect.DeepEqual(items, expected) {
+			t.Errorf("ForEachStride() items got = %v, want %v", items, expected)
+		}
+	})
+
+	t.Run("treats stride < 1 as every element", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		calls := 0
+		ForEachStride(input, 0, func(_ int, _ int) {
+			calls++
+		})
+		if calls != len(input) {
+			t.Errorf("ForEachStride() calls got = %v, want %v", calls, len(input))
+		}
+	})
+
+	t.Run("does nothing for nil slice", func(t *testing.T) {
+		var input []int
+		called := false
+		ForEachStride(input, 2, func(_ int, _ int) {
+			called = true
+		})
+		if called {
+			t.Errorf("ForEachStride() should not call function for nil slice")
+		}
+	})
+}
+
+func TestForEachRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+
+	t.Run("retries an element that fails twice then succeeds", func(t *testing.T) {
+		input := []string{"a", "b"}
+		calls := map[string]int{}
+		err := ForEachRetry(input, 3, func(item string, _ int) error {
+			calls[item]++
+			if item == "a" && calls[item] <= 2 {
+				return errTransient
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("ForEachRetry() unexpected error: %v", err)
+		}
+		expected := map[string]int{"a": 3, "b": 1}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Errorf("ForEachRetry() calls got = %v, want %v", calls, expected)
+		}
+	})
+
+	t.Run("returns the last error when an element always fails", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		attempts := 0
+		var visited []int
+		err := ForEachRetry(input, 2, func(item int, _ int) error {
+			visited = append(visited, item)
+			if item == 2 {
+				attempts++
+				return fmt.Errorf("attempt %d: %w", attempts, errTransient)
+			}
+			return nil
+		})
+		if err == nil || err.Error() != "attempt 2: transient" {
+			t.Errorf("ForEachRetry() error got = %v, want the last attempt's error", err)
+		}
+		expected := []int{1, 2, 2}
+		if !reflect.DeepEqual(visited, expected) {
+			t.Errorf("ForEachRetry() should stop after the failing element, visited %v, want %v", visited, expected)
+		}
+	})
+
+	t.Run("treats attempts <= 0 as a single attempt", func(t *testing.T) {
+		calls := 0
+		err := ForEachRetry([]int{1}, 0, func(_ int, _ int) error {
+			calls++
+			return errTransient
+		})
+		if !errors.Is(err, errTransient) || calls != 1 {
+			t.Errorf("ForEachRetry() got err = %v after %d calls, want %v after 1 call", err, calls, errTransient)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		err := ForEachRetry(input, 3, func(_ int, _ int) error { return errTransient })
+		if err != nil {
+			t.Errorf("ForEachRetry() on nil slice should return nil, but got %v", err)
+		}
+	})
+}
+
+func TestForEachTimed(t *testing.T) {
+	t.Run("returns one duration per element", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		var visited []int
+		durations := ForEachTimed(input, func(item int, _ int) {
+			visited = append(visited, item)
+		})
+		if len(durations) != len(input) {
+			t.Fatalf("ForEachTimed() returned %d durations, want %d", len(durations), len(input))
+		}
+		if !reflect.DeepEqual(visited, input) {
+			t.Errorf("ForEachTimed() visited %v, want %v", visited, input)
+		}
+		for i, d := range durations {
+			if d < 0 {
+				t.Errorf("ForEachTimed() duration %d got = %v, want non-negative", i, d)
+			}
+		}
+	})
+
+	t.Run("attributes time to the slow element", func(t *testing.T) {
+		const delay = 5 * time.Millisecond
+		durations := ForEachTimed([]int{0, 1, 0}, func(item int, _ int) {
+			if item == 1 {
+				time.Sleep(delay)
+			}
+		})
+		if durations[1] < delay {
+			t.Errorf("ForEachTimed() slow element took %v, want at least %v", durations[1], delay)
+		}
+	})
+
+	t.Run("returns empty slice for empty input and nil for nil input", func(t *testing.T) {
+		noop := func(_ int, _ int) {}
+		if durations := ForEachTimed([]int{}, noop); durations == nil || len(durations) != 0 {
+			t.Errorf("ForEachTimed() on empty slice should return empty slice, but got %v", durations)
+		}
+		if durations := ForEachTimed([]int(nil), noop); durations != nil {
+			t.Errorf("ForEachTimed() on nil slice should return nil, but got %v", durations)
+		}
+	})
+}
+
+func TestEachChunkIndexed(t *testing.T) {
+	t.Run("passes incrementing chunk indices", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7}
+		var indices []int
+		var chunks [][]int
+		err := EachChunkIndexed(input, 3, func(chunkIndex int, chunk []int) error {
+			indices = append(indices, chunkIndex)
+			chunks = append(chunks, chunk)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("EachChunkIndexed() unexpected error: %v", err)
+		}
+		if expected := []int{0, 1, 2}; !reflect.DeepEqual(indices, expected) {
+			t.Errorf("EachChunkIndexed() indices got = %v, want %v", indices, expected)
+		}
+		if expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}; !reflect.DeepEqual(chunks, expected) {
+			t.Errorf("EachChunkIndexed() chunks got = %v, want %v", chunks, expected)
+		}
+	})
+
+	t.Run("aborts on an error in the middle", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var indices []int
+		err := EachChunkIndexed([]int{1, 2, 3, 4, 5, 6}, 2, func(chunkIndex int, _ []int) error {
+			indices = append(indices, chunkIndex)
+			if chunkIndex == 1 {
+				return errStop
+			}
+			return nil
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("EachChunkIndexed() error got = %v, want %v", err, errStop)
+		}
+		if expected := []int{0, 1}; !reflect.DeepEqual(indices, expected) {
+			t.Errorf("EachChunkIndexed() should stop at the failing chunk, visited %v, want %v", indices, expected)
+		}
+	})
+
+	t.Run("returns error for size less than 1", func(t *testing.T) {
+		err := EachChunkIndexed([]int{1}, 0, func(_ int, _ []int) error { return nil })
+		if !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("EachChunkIndexed() error got = %v, want %v", err, ErrInvalidSize)
+		}
+	})
+
+	t.Run("nil slice is a no-op", func(t *testing.T) {
+		calls := 0
+		err := EachChunkIndexed([]int(nil), 2, func(_ int, _ []int) error {
+			calls++
+			return nil
+		})
+		if err != nil || calls != 0 {
+			t.Errorf("EachChunkIndexed() on nil slice got err = %v after %d calls, want nil after 0 calls", err, calls)
+		}
+	})
+}
+
 func TestReverse(t *testing.T) {
 	t.Run("reverses elements in slice", func(t *testing.T) {
 		input := []int{1, 2, 3, 4, 5}
@@ -312,6 +634,46 @@ func TestReverse(t *testing.T) {
 	})
 }
 
+func TestReverseInPlace(t *testing.T) {
+	t.Run("reverses the caller's slice", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := []int{5, 4, 3, 2, 1}
+		ReverseInPlace(input)
+		if !reflect.DeepEqual(input, expected) {
+			t.Errorf("ReverseInPlace() got = %v, want %v", input, expected)
+		}
+	})
+
+	t.Run("handles even length", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d"}
+		expected := []string{"d", "c", "b", "a"}
+		ReverseInPlace(input)
+		if !reflect.DeepEqual(input, expected) {
+			t.Errorf("ReverseInPlace() got = %v, want %v", input, expected)
+		}
+	})
+
+	t.Run("is a no-op for nil, empty and single-element slices", func(t *testing.T) {
+		var nilInput []int
+		ReverseInPlace(nilInput)
+		if nilInput != nil {
+			t.Errorf("ReverseInPlace() on nil slice should leave it nil, but got %v", nilInput)
+		}
+
+		empty := []int{}
+		ReverseInPlace(empty)
+		if len(empty) != 0 {
+			t.Errorf("ReverseInPlace() on empty slice should leave it empty, but got %v", empty)
+		}
+
+		single := []int{7}
+		ReverseInPlace(single)
+		if !reflect.DeepEqual(single, []int{7}) {
+			t.Errorf("ReverseInPlace() got = %v, want %v", single, []int{7})
+		}
+	})
+}
+
 func TestTake(t *testing.T) {
 	t.Run("takes first n elements", func(t *testing.T) {
 		input := []int{1, 2, 3, 4, 5}
@@ -385,3 +747,500 @@ func TestDrop(t *testing.T) {
 		}
 	})
 }
+
+func TestTakeUntil(t *testing.T) {
+	isNegative := func(item int, _ int) bool { return item < 0 }
+
+	t.Run("returns elements before the first match", func(t *testing.T) {
+		expected := []int{1, 2}
+		result := TakeUntil([]int{1, 2, -3, 4, -5}, isNegative)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TakeUntil() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when the first element matches", func(t *testing.T) {
+		result := TakeUntil([]int{-1, 2, 3}, isNegative)
+		if result == nil || len(result) != 0 {
+			t.Errorf("TakeUntil() should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns the whole slice when nothing matches", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		result := TakeUntil(input, isNegative)
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("TakeUntil() got = %v, want %v", result, input)
+		}
+		result[0] = 99
+		if input[0] != 1 {
+			t.Errorf("TakeUntil() should return a clone, but the input was modified to %v", input)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := TakeUntil([]int(nil), isNegative); result != nil {
+			t.Errorf("TakeUntil() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestDropUntil(t *testing.T) {
+	isNegative := func(item int, _ int) bool { return item < 0 }
+
+	t.Run("returns elements from the first match onward", func(t *testing.T) {
+		expected := []int{-3, 4, -5}
+		result := DropUntil([]int{1, 2, -3, 4, -5}, isNegative)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("DropUntil() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns the whole slice when the first element matches", func(t *testing.T) {
+		input := []int{-1, 2, 3}
+		result := DropUntil(input, isNegative)
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("DropUntil() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("returns empty slice when nothing matches", func(t *testing.T) {
+		result := DropUntil([]int{1, 2, 3}, isNegative)
+		if result == nil || len(result) != 0 {
+			t.Errorf("DropUntil() should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := DropUntil([]int(nil), isNegative); result != nil {
+			t.Errorf("DropUntil() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestFirstN(t *testing.T) {
+	t.Run("returns first n elements", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := []int{1, 2}
+		result := FirstN(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FirstN() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns a clone when n > length", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{1, 2, 3}
+		result := FirstN(input, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FirstN() got = %v, want %v", result, expected)
+		}
+		result[0] = 99
+		if input[0] == 99 {
+			t.Errorf("FirstN() should return a clone, not alias the input slice")
+		}
+	})
+
+	t.Run("returns empty slice when n is zero", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{}
+		result := FirstN(input, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FirstN() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := FirstN(input, 2)
+		if result != nil {
+			t.Errorf("FirstN() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestLastN(t *testing.T) {
+	t.Run("returns last n elements", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := []int{4, 5}
+		result := LastN(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("LastN() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns a clone when n > length", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{1, 2, 3}
+		result := LastN(input, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("LastN() got = %v, want %v", result, expected)
+		}
+		result[0] = 99
+		if input[0] == 99 {
+			t.Errorf("LastN() should return a clone, not alias the input slice")
+		}
+	})
+
+	t.Run("returns empty slice when n is zero", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{}
+		result := LastN(input, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("LastN() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := LastN(input, 2)
+		if result != nil {
+			t.Errorf("LastN() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestGetOr(t *testing.T) {
+	t.Run("returns element at in-range positive index", func(t *testing.T) {
+		input := []int{10, 20, 30}
+		expected := 20
+		result := GetOr(input, 1, -1)
+		if result != expected {
+			t.Errorf("GetOr() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns element at in-range negative index", func(t *testing.T) {
+		input := []int{10, 20, 30}
+		expected := 30
+		result := GetOr(input, -1, -1)
+		if result != expected {
+			t.Errorf("GetOr() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns fallback for out-of-range indices", func(t *testing.T) {
+		input := []int{10, 20, 30}
+		for _, index := range []int{3, 100, -4, -100} {
+			if result := GetOr(input, index, -1); result != -1 {
+				t.Errorf("GetOr() at index %d got = %v, want %v", index, result, -1)
+			}
+		}
+	})
+
+	t.Run("returns fallback for nil slice", func(t *testing.T) {
+		var input []string
+		expected := "default"
+		result := GetOr(input, 0, "default")
+		if result != expected {
+			t.Errorf("GetOr() got = %v, want %v", result, expected)
+		}
+	})
+}
+
+func TestSetEqual(t *testing.T) {
+	t.Run("ignores order and multiplicity", func(t *testing.T) {
+		// These differ as multisets (1 occurs twice vs once) but are equal as sets.
+		a := []int{1, 1, 2}
+		b := []int{2, 1}
+		if !SetEqual(a, b) {
+			t.Errorf("SetEqual(%v, %v) should be true", a, b)
+		}
+	})
+
+	t.Run("returns false when a value is missing", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		b := []int{1, 2}
+		if SetEqual(a, b) {
+			t.Errorf("SetEqual(%v, %v) should be false", a, b)
+		}
+		if SetEqual(b, a) {
+			t.Errorf("SetEqual(%v, %v) should be false", b, a)
+		}
+	})
+
+	t.Run("returns false for disjoint values of equal size", func(t *testing.T) {
+		a := []string{"a", "b"}
+		b := []string{"a", "c"}
+		if SetEqual(a, b) {
+			t.Errorf("SetEqual(%v, %v) should be false", a, b)
+		}
+	})
+
+	t.Run("treats nil and empty slices as equal", func(t *testing.T) {
+		var nilSlice []int
+		if !SetEqual(nilSlice, nil) {
+			t.Errorf("SetEqual() of two nil slices should be true")
+		}
+		if !SetEqual(nilSlice, []int{}) {
+			t.Errorf("SetEqual() of nil and empty slices should be true")
+		}
+	})
+}
+
+func TestRotateTo(t *testing.T) {
+	t.Run("rotates so that the value comes first", func(t *testing.T) {
+		input := []int{3, 4, 5, 1, 2}
+		expected := []int{1, 2, 3, 4, 5}
+		result := RotateTo(input, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RotateTo() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("uses the first occurrence of the value", func(t *testing.T) {
+		input := []int{9, 1, 7, 1}
+		expected := []int{1, 7, 1, 9}
+		result := RotateTo(input, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RotateTo() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns an unchanged clone when value is not found", func(t *testing.T) {
+		input := []int{3, 4, 5}
+		expected := []int{3, 4, 5}
+		result := RotateTo(input, 42)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RotateTo() got = %v, want %v", result, expected)
+		}
+		result[0] = 99
+		if input[0] == 99 {
+			t.Errorf("RotateTo() should return a clone, not alias the input slice")
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := RotateTo(input, 1)
+		if result != nil {
+			t.Errorf("RotateTo() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSwap(t *testing.T) {
+	t.Run("swaps two elements without modifying the input", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		expected := []int{1, 4, 3, 2}
+		result := Swap(input, 1, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Swap() got = %v, want %v", result, expected)
+		}
+		if !reflect.DeepEqual(input, []int{1, 2, 3, 4}) {
+			t.Errorf("Swap() modified input: got %v", input)
+		}
+	})
+
+	t.Run("is a no-op when i == j", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{1, 2, 3}
+		result := Swap(input, 1, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Swap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns an unchanged clone for out-of-range indices", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{1, 2, 3}
+		for _, idx := range [][2]int{{-1, 0}, {0, 3}, {5, 5}} {
+			result := Swap(input, idx[0], idx[1])
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Swap(%d, %d) got = %v, want %v", idx[0], idx[1], result, expected)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Swap(input, 0, 1)
+		if result != nil {
+			t.Errorf("Swap() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMove(t *testing.T) {
+	t.Run("moves an element forward", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d", "e"}
+		expected := []string{"a", "c", "d", "b", "e"}
+		result := Move(input, 1, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Move() got = %v, want %v", result, expected)
+		}
+		if !reflect.DeepEqual(input, []string{"a", "b", "c", "d", "e"}) {
+			t.Errorf("Move() modified input: got %v", input)
+		}
+	})
+
+	t.Run("moves an element backward", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d", "e"}
+		expected := []string{"d", "a", "b", "c", "e"}
+		result := Move(input, 3, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Move() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("clamps out-of-range indices", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		expected := []string{"b", "c", "a"}
+		result := Move(input, -5, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Move() got = %v, want %v", result, expected)
+		}
+
+		expected = []string{"c", "a", "b"}
+		result = Move(input, 99, -1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Move() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []int{}
+		expected := []int{}
+		result := Move(input, 0, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Move() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Move(input, 0, 1)
+		if result != nil {
+			t.Errorf("Move() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestIntersperse(t *testing.T) {
+	t.Run("places the separator between elements", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		expected := []string{"a", "x", "b", "x", "c"}
+		result := Intersperse(input, "x")
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Intersperse() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns a clone for a single element", func(t *testing.T) {
+		input := []int{1}
+		expected := []int{1}
+		result := Intersperse(input, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Intersperse() got = %v, want %v", result, expected)
+		}
+		result[0] = 99
+		if input[0] == 99 {
+			t.Errorf("Intersperse() should return a clone, not alias the input slice")
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []int{}
+		expected := []int{}
+		result := Intersperse(input, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Intersperse() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Intersperse(input, 0)
+		if result != nil {
+			t.Errorf("Intersperse() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestCoalesceAdjacent(t *testing.T) {
+	type interval struct{ start, end int }
+	overlaps := func(a, b interval) bool { return b.start <= a.end }
+	combine := func(a, b interval) interval { return interval{a.start, max(a.end, b.end)} }
+
+	t.Run("merges adjacent overlapping intervals", func(t *testing.T) {
+		input := []interval{{1, 3}, {2, 5}, {4, 6}, {8, 9}, {10, 12}, {11, 11}}
+		expected := []interval{{1, 6}, {8, 9}, {10, 12}}
+		result := CoalesceAdjacent(input, overlaps, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("CoalesceAdjacent() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("keeps elements that cannot merge", func(t *testing.T) {
+		input := []interval{{1, 2}, {4, 5}}
+		result := CoalesceAdjacent(input, overlaps, combine)
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("CoalesceAdjacent() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("sums runs of equal values", func(t *testing.T) {
+		type run struct{ value, count int }
+		input := []run{{1, 1}, {1, 1}, {2, 1}, {1, 1}, {1, 1}, {1, 1}}
+		expected := []run{{1, 2}, {2, 1}, {1, 3}}
+		result := CoalesceAdjacent(input,
+			func(a, b run) bool { return a.value == b.value },
+			func(a, b run) run { return run{a.value, a.count + b.count} })
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("CoalesceAdjacent() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := CoalesceAdjacent([]interval{}, overlaps, combine)
+		if result == nil || len(result) != 0 {
+			t.Errorf("CoalesceAdjacent() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := CoalesceAdjacent([]interval(nil), overlaps, combine); result != nil {
+			t.Errorf("CoalesceAdjacent() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestCycle(t *testing.T) {
+	t.Run("repeats the input to the target length", func(t *testing.T) {
+		expected := []string{"a", "b", "a", "b", "a"}
+		result := Cycle([]string{"a", "b"}, 5)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Cycle() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("truncates when length is shorter than the input", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		result := Cycle(input, 2)
+		if !reflect.DeepEqual(result, []int{1, 2}) {
+			t.Errorf("Cycle() got = %v, want %v", result, []int{1, 2})
+		}
+		result[0] = 99
+		if input[0] != 1 {
+			t.Errorf("Cycle() should return a new slice, but the input was modified to %v", input)
+		}
+	})
+
+	t.Run("returns empty slice for non-positive length", func(t *testing.T) {
+		for _, length := range []int{0, -1} {
+			result := Cycle([]int{1}, length)
+			if result == nil || len(result) != 0 {
+				t.Errorf("Cycle(length=%d) should return empty slice, but got %v", length, result)
+			}
+		}
+	})
+
+	t.Run("returns empty slice for nil or empty input", func(t *testing.T) {
+		for _, input := range [][]int{nil, {}} {
+			result := Cycle(input, 3)
+			if result == nil || len(result) != 0 {
+				t.Errorf("Cycle(%v, 3) should return empty slice, but got %v", input, result)
+			}
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "context"
+
+// ToChannel streams the elements of a slice, in order, into the returned channel and closes
+// it once every element has been sent or ctx is cancelled, whichever comes first.
+//
+// The channel is unbuffered so that the producer advances only as fast as the consumer
+// receives; this keeps cancellation prompt and means at most one element is in flight when
+// ctx is done. Consumers that stop reading early must cancel ctx so the producing goroutine
+// can exit. For nil input the returned channel is already closed.
+func ToChannel[S ~[]E, E any](ctx context.Context, collection S) <-chan E {
+	out := make(chan E)
+	if collection == nil {
+		close(out)
+		return out
+	}
+
+	go func() {
+		defer close(out)
+		for _, item := range collection {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- item:
+			}
+		}
+	}()
+	return out
+}
+
+// FromChannel drains ch into a slice, stopping when the channel is closed, ctx is
+// cancelled, or limit elements have been collected, whichever comes first. A limit less
+// than or equal to 0 means no limit. It returns whatever was collected, which is an empty
+// (non-nil) slice if nothing was received. For a nil channel it returns nil.
+func FromChannel[E any](ctx context.Context, ch <-chan E, limit int) []E {
+	if ch == nil {
+		return nil
+	}
+
+	result := []E{}
+	for limit <= 0 || len(result) < limit {
+		select {
+		case <-ctx.Done():
+			return result
+		case item, ok := <-ch:
+			if !ok {
+				return result
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToChannel(t *testing.T) {
+	t.Run("emits each element in order and closes", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		var result []int
+		for v := range ToChannel(context.Background(), input) {
+			result = append(result, v)
+		}
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("ToChannel() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("stops emitting and closes when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		input := make([]int, 1000)
+		ch := ToChannel(ctx, input)
+
+		<-ch
+		<-ch
+		cancel()
+
+		received := 2
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					if received >= len(input) {
+						t.Errorf("ToChannel() emitted all %d elements despite cancellation", received)
+					}
+					return
+				}
+				received++
+			case <-timeout:
+				t.Fatalf("ToChannel() channel was not closed after cancellation")
+			}
+		}
+	})
+
+	t.Run("returns a closed channel for nil input", func(t *testing.T) {
+		var input []int
+		ch := ToChannel(context.Background(), input)
+		select {
+		case _, ok := <-ch:
+			if ok {
+				t.Errorf("ToChannel() on nil slice should not emit values")
+			}
+		default:
+			t.Errorf("ToChannel() on nil slice should return a closed channel")
+		}
+	})
+}
+
+func TestFromChannel(t *testing.T) {
+	t.Run("collects until the channel closes", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+		expected := []int{1, 2, 3}
+		result := FromChannel(context.Background(), ch, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FromChannel() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("stops early at the limit", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		expected := []int{1, 2}
+		result := FromChannel(ctx, ToChannel(ctx, []int{1, 2, 3, 4}), 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FromChannel() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns what was collected when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		go func() {
+			ch <- 1
+			cancel()
+		}()
+		expected := []int{1}
+		result := FromChannel(ctx, ch, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FromChannel() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for a closed empty channel", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+		result := FromChannel(context.Background(), ch, 0)
+		if result == nil || len(result) != 0 {
+			t.Errorf("FromChannel() on closed empty channel should return empty non-nil slice, got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil channel", func(t *testing.T) {
+		var ch chan int
+		result := FromChannel(context.Background(), ch, 0)
+		if result != nil {
+			t.Errorf("FromChannel() on nil channel should return nil, but got %v", result)
+		}
+	})
+}
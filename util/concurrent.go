@@ -0,0 +1,132 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrInvalidSize is returned when a chunk or batch size is less than 1.
+var ErrInvalidSize = errors.New("util: size must be at least 1")
+
+// MapChunksParallel splits a slice into chunks of the given size and processes up to
+// workers chunks concurrently with fn. The per-chunk results are concatenated in chunk
+// order, so the output order does not depend on scheduling.
+//
+// If any call to fn fails, no further chunks are started and the first error observed is
+// returned with a nil result; chunks already in flight are allowed to finish. Each chunk is
+// a capacity-limited view into collection, so fn must not modify it. A workers value less
+// than 1 is treated as 1. It returns (nil, nil) for nil input and ErrInvalidSize if size is
+// less than 1.
+func MapChunksParallel[S ~[]E, E, R any](
+	collection S,
+	size, workers int,
+	fn func(chunk S) ([]R, error),
+) ([]R, error) {
+	if collection == nil {
+		return nil, nil
+	}
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	chunks := Chunk(collection, size)
+	workers = max(1, min(workers, len(chunks)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	results := make([][]R, len(chunks))
+	jobs := make(chan int)
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// The dispatcher may still hand out a job after cancellation, since
+				// select picks randomly among ready cases; skip it rather than run fn.
+				if ctx.Err() != nil {
+					continue
+				}
+				out, err := fn(slices.Clip(chunks[i]))
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = out
+			}
+		}()
+	}
+
+dispatch:
+	for i := range chunks {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return Flatten(results), nil
+}
+
+// MapUnordered applies iteratee to each element of a slice using up to workers goroutines
+// and returns the results in completion order rather than input order. The order of the
+// returned slice is therefore unspecified; use it when results are independent and the
+// cost of reassembling input order is not worth paying.
+// A workers value less than 1 is treated as 1. It returns nil for nil input and an empty
+// (non-nil) slice for empty input.
+func MapUnordered[S ~[]E, E, R any](collection S, iteratee func(item E, index int) R, workers int) []R {
+	if collection == nil {
+		return nil
+	}
+	if len(collection) == 0 {
+		return []R{}
+	}
+
+	workers = max(1, min(workers, len(collection)))
+	jobs := make(chan int)
+	results := make(chan R, workers)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- iteratee(collection[i], i)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range collection {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	output := make([]R, 0, len(collection))
+	for result := range results {
+		output = append(output, result)
+	}
+	return output
+}
@@ -0,0 +1,179 @@
+package util
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMapChunksParallel(t *testing.T) {
+	double := func(chunk []int) ([]int, error) {
+		out := make([]int, len(chunk))
+		for i, v := range chunk {
+			out[i] = v * 2
+		}
+		return out, nil
+	}
+
+	t.Run("preserves chunk order in the flattened result", func(t *testing.T) {
+		input := make([]int, 1000)
+		for i := range input {
+			input[i] = i
+		}
+		result, err := MapChunksParallel(input, 7, 8, double)
+		if err != nil {
+			t.Fatalf("MapChunksParallel() unexpected error: %v", err)
+		}
+		for i, v := range result {
+			if v != i*2 {
+				t.Fatalf("MapChunksParallel() result[%d] got = %v, want %v", i, v, i*2)
+			}
+		}
+		if len(result) != len(input) {
+			t.Errorf("MapChunksParallel() length got = %d, want %d", len(result), len(input))
+		}
+	})
+
+	t.Run("limits concurrency to the number of workers", func(t *testing.T) {
+		var active, peak atomic.Int32
+		input := make([]int, 100)
+		_, err := MapChunksParallel(input, 1, 3, func(chunk []int) ([]int, error) {
+			current := active.Add(1)
+			for {
+				seen := peak.Load()
+				if current <= seen || peak.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			active.Add(-1)
+			return chunk, nil
+		})
+		if err != nil {
+			t.Fatalf("MapChunksParallel() unexpected error: %v", err)
+		}
+		if peak.Load() > 3 {
+			t.Errorf("MapChunksParallel() ran %d chunks concurrently, want at most 3", peak.Load())
+		}
+	})
+
+	t.Run("propagates the first error and stops dispatching", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		var calls atomic.Int32
+		input := make([]int, 1000)
+		result, err := MapChunksParallel(input, 1, 1, func(chunk []int) ([]int, error) {
+			if calls.Add(1) == 3 {
+				return nil, errBoom
+			}
+			return chunk, nil
+		})
+		if !errors.Is(err, errBoom) {
+			t.Errorf("MapChunksParallel() error got = %v, want %v", err, errBoom)
+		}
+		if result != nil {
+			t.Errorf("MapChunksParallel() on error should return nil result, but got %v", result)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("MapChunksParallel() should stop calling fn after an error, called it %d times, want 3", calls.Load())
+		}
+	})
+
+	t.Run("treats workers < 1 as a single worker", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{2, 4, 6}
+		result, err := MapChunksParallel(input, 2, 0, double)
+		if err != nil {
+			t.Fatalf("MapChunksParallel() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapChunksParallel() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns error for invalid size", func(t *testing.T) {
+		_, err := MapChunksParallel([]int{1}, 0, 2, double)
+		if !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("MapChunksParallel() error got = %v, want %v", err, ErrInvalidSize)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result, err := MapChunksParallel([]int{}, 2, 2, double)
+		if err != nil || result == nil || len(result) != 0 {
+			t.Errorf("MapChunksParallel() on empty slice got = (%v, %v), want ([], nil)", result, err)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result, err := MapChunksParallel(input, 2, 2, double)
+		if result != nil || err != nil {
+			t.Errorf("MapChunksParallel() on nil slice got = (%v, %v), want (nil, nil)", result, err)
+		}
+	})
+}
+
+func TestMapUnordered(t *testing.T) {
+	square := func(item int, _ int) int { return item * item }
+
+	t.Run("produces the same multiset of results as Map", func(t *testing.T) {
+		input := make([]int, 500)
+		for i := range input {
+			input[i] = i - 250
+		}
+		result := MapUnordered(input, square, 8)
+		expected := Map(input, square)
+
+		counts := make(map[int]int)
+		for _, v := range expected {
+			counts[v]++
+		}
+		for _, v := range result {
+			counts[v]--
+		}
+		for v, count := range counts {
+			if count != 0 {
+				t.Fatalf("MapUnordered() result count mismatch for %v: off by %d", v, count)
+			}
+		}
+		if len(result) != len(expected) {
+			t.Errorf("MapUnordered() length got = %d, want %d", len(result), len(expected))
+		}
+	})
+
+	t.Run("passes the original index to the iteratee", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		var sum atomic.Int32
+		MapUnordered(input, func(_ string, index int) int {
+			sum.Add(int32(index))
+			return index
+		}, 3)
+		if sum.Load() != 3 {
+			t.Errorf("MapUnordered() index sum got = %v, want 3", sum.Load())
+		}
+	})
+
+	t.Run("treats workers < 1 as a single worker", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{1, 4, 9}
+		result := MapUnordered(input, square, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapUnordered() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := MapUnordered([]int{}, square, 4)
+		if result == nil || len(result) != 0 {
+			t.Errorf("MapUnordered() on empty slice should return empty non-nil slice, got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := MapUnordered(input, square, 4)
+		if result != nil {
+			t.Errorf("MapUnordered() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "sync"
+
+// Counter counts occurrences of comparable values. It is safe for concurrent use by
+// multiple goroutines, which makes it suitable for incremental aggregation across workers
+// without manually locking a map. The zero value is ready to use.
+type Counter[E comparable] struct {
+	mu     sync.Mutex
+	counts map[E]int
+}
+
+// NewCounter returns an empty Counter.
+func NewCounter[E comparable]() *Counter[E] {
+	return &Counter[E]{counts: make(map[E]int)}
+}
+
+// Add increments the count of item by one.
+func (c *Counter[E]) Add(item E) {
+	c.AddN(item, 1)
+}
+
+// AddN increments the count of item by n. A negative n decrements the count.
+func (c *Counter[E]) AddN(item E, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil {
+		c.counts = make(map[E]int)
+	}
+	c.counts[item] += n
+}
+
+// Count returns the current count of item, or 0 if it has never been added.
+func (c *Counter[E]) Count(item E) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counts[item]
+}
+
+// ToMap returns a snapshot of all counts. The returned map is a copy and may be modified
+// freely without affecting the Counter.
+func (c *Counter[E]) ToMap() map[E]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make(map[E]int, len(c.counts))
+	for item, count := range c.counts {
+		result[item] = count
+	}
+	return result
+}
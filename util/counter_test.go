@@ -0,0 +1,75 @@
+package util
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	t.Run("counts added items", func(t *testing.T) {
+		counter := NewCounter[string]()
+		counter.Add("a")
+		counter.Add("b")
+		counter.AddN("a", 3)
+		if got := counter.Count("a"); got != 4 {
+			t.Errorf("Counter.Count(a) got = %v, want 4", got)
+		}
+		if got := counter.Count("missing"); got != 0 {
+			t.Errorf("Counter.Count(missing) got = %v, want 0", got)
+		}
+	})
+
+	t.Run("returns a snapshot copy from ToMap", func(t *testing.T) {
+		counter := NewCounter[int]()
+		counter.Add(1)
+		counter.AddN(2, 2)
+		snapshot := counter.ToMap()
+		expected := map[int]int{1: 1, 2: 2}
+		if !reflect.DeepEqual(snapshot, expected) {
+			t.Errorf("Counter.ToMap() got = %v, want %v", snapshot, expected)
+		}
+		snapshot[1] = 100
+		if counter.Count(1) != 1 {
+			t.Errorf("Counter.ToMap() should return a copy, not the internal map")
+		}
+	})
+
+	t.Run("zero value is ready to use", func(t *testing.T) {
+		var counter Counter[string]
+		counter.Add("x")
+		if got := counter.Count("x"); got != 1 {
+			t.Errorf("Counter.Count(x) got = %v, want 1", got)
+		}
+	})
+
+	t.Run("is safe for concurrent increments", func(t *testing.T) {
+		counter := NewCounter[string]()
+		const goroutines, increments = 8, 1000
+
+		var wg sync.WaitGroup
+		for range goroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range increments {
+					if i%2 == 0 {
+						counter.Add("even")
+					} else {
+						counter.AddN("odd", 2)
+					}
+					_ = counter.Count("even")
+				}
+			}()
+		}
+		wg.Wait()
+
+		expected := map[string]int{
+			"even": goroutines * increments / 2,
+			"odd":  goroutines * increments,
+		}
+		if got := counter.ToMap(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Counter.ToMap() got = %v, want %v", got, expected)
+		}
+	})
+}
@@ -0,0 +1,178 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidEdits is returned by ApplyEdits when an edit script does not fit the slice it
+// is applied to.
+var ErrInvalidEdits = errors.New("util: edit script does not match slice")
+
+// CommonPrefixLen returns the number of leading elements that a and b have in common.
+// It returns 0 if either slice is nil or empty.
+func CommonPrefixLen[S ~[]E, E comparable](a, b S) int {
+	limit := min(len(a), len(b))
+	for i := range limit {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return limit
+}
+
+// CommonSuffixLen returns the number of trailing elements that a and b have in common.
+// It returns 0 if either slice is nil or empty.
+func CommonSuffixLen[S ~[]E, E comparable](a, b S) int {
+	limit := min(len(a), len(b))
+	for i := range limit {
+		if a[len(a)-1-i] != b[len(b)-1-i] {
+			return i
+		}
+	}
+	return limit
+}
+
+// EditOp identifies the kind of operation in an edit script produced by DiffEdits.
+type EditOp int
+
+const (
+	// EditKeep leaves the current element of the old slice in place.
+	EditKeep EditOp = iota
+	// EditInsert adds a new element that is not present in the old slice.
+	EditInsert
+	// EditDelete removes the current element of the old slice.
+	EditDelete
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "Keep"
+	case EditInsert:
+		return "Insert"
+	case EditDelete:
+		return "Delete"
+	default:
+		return "EditOp(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// Edit is a single step of an edit script. For EditKeep and EditDelete, Value is the
+// element of the old slice being kept or removed; for EditInsert it is the inserted element.
+type Edit[E any] struct {
+	Op    EditOp
+	Value E
+}
+
+// DiffEdits returns a minimal edit script that transforms before into after. Reading the
+// script in order, EditKeep and EditDelete consume the next element of before, and EditKeep
+// and EditInsert produce the next element of after. Within each changed region, deletions
+// are listed before insertions.
+//
+// The common prefix and suffix are matched in linear time; the remaining middle section is
+// diffed via a longest common subsequence table, costing O(n*m) time and memory in the size
+// of that section. Nil inputs behave as empty; the result is nil only when both are empty.
+func DiffEdits[S ~[]E, E comparable](before, after S) []Edit[E] {
+	if len(before) == 0 && len(after) == 0 {
+		return nil
+	}
+
+	prefix := CommonPrefixLen(before, after)
+	suffix := CommonSuffixLen(before[prefix:], after[prefix:])
+	a := before[prefix : len(before)-suffix]
+	b := after[prefix : len(after)-suffix]
+
+	edits := make([]Edit[E], 0, len(before)+len(b))
+	for _, item := range before[:prefix] {
+		edits = append(edits, Edit[E]{Op: EditKeep, Value: item})
+	}
+
+	// lcs[i][j] holds the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit[E]{Op: EditKeep, Value: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit[E]{Op: EditDelete, Value: a[i]})
+			i++
+		default:
+			edits = append(edits, Edit[E]{Op: EditInsert, Value: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, Edit[E]{Op: EditDelete, Value: a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, Edit[E]{Op: EditInsert, Value: b[j]})
+	}
+
+	for _, item := range before[len(before)-suffix:] {
+		edits = append(edits, Edit[E]{Op: EditKeep, Value: item})
+	}
+	return edits
+}
+
+// ApplyEdits applies an edit script, as produced by DiffEdits, to before and returns the
+// resulting slice, so ApplyEdits(before, DiffEdits(before, after)) equals after.
+// EditKeep and EditDelete consume the next element of before, and EditKeep and EditInsert
+// append to the result.
+//
+// The script is validated as it is applied: it returns nil and an error wrapping
+// ErrInvalidEdits if a Keep or Delete value differs from the element it consumes, if the
+// script runs past the end of before or does not consume all of it, or if it contains an
+// unknown operation. Elements are compared, which is why E must be comparable.
+// It returns (nil, nil) when both before and edits are empty.
+func ApplyEdits[E comparable](before []E, edits []Edit[E]) ([]E, error) {
+	if len(before) == 0 && len(edits) == 0 {
+		return nil, nil
+	}
+
+	result := make([]E, 0, len(edits))
+	position := 0
+	for step, edit := range edits {
+		switch edit.Op {
+		case EditKeep, EditDelete:
+			if position >= len(before) {
+				return nil, fmt.Errorf("%w: %v at step %d runs past the end", ErrInvalidEdits, edit.Op, step)
+			}
+			if before[position] != edit.Value {
+				return nil, fmt.Errorf("%w: %v at step %d does not match element %d", ErrInvalidEdits, edit.Op, step, position)
+			}
+			if edit.Op == EditKeep {
+				result = append(result, before[position])
+			}
+			position++
+		case EditInsert:
+			result = append(result, edit.Value)
+		default:
+			return nil, fmt.Errorf("%w: unknown %v at step %d", ErrInvalidEdits, edit.Op, step)
+		}
+	}
+
+	if position != len(before) {
+		return nil, fmt.Errorf("%w: %d trailing elements not consumed", ErrInvalidEdits, len(before)-position)
+	}
+	return result, nil
+}
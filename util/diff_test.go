@@ -0,0 +1,226 @@
+package util
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCommonPrefixLen(t *testing.T) {
+	t.Run("counts matching leading elements", func(t *testing.T) {
+		a := []int{1, 2, 3, 4}
+		b := []int{1, 2, 9, 4}
+		if got := CommonPrefixLen(a, b); got != 2 {
+			t.Errorf("CommonPrefixLen() got = %v, want 2", got)
+		}
+	})
+
+	t.Run("is bounded by the shorter slice", func(t *testing.T) {
+		a := []string{"a", "b"}
+		b := []string{"a", "b", "c"}
+		if got := CommonPrefixLen(a, b); got != 2 {
+			t.Errorf("CommonPrefixLen() got = %v, want 2", got)
+		}
+	})
+
+	t.Run("returns full length for identical slices", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		if got := CommonPrefixLen(a, []int{1, 2, 3}); got != 3 {
+			t.Errorf("CommonPrefixLen() got = %v, want 3", got)
+		}
+	})
+
+	t.Run("returns 0 for nil or empty input", func(t *testing.T) {
+		if got := CommonPrefixLen(nil, []int{1}); got != 0 {
+			t.Errorf("CommonPrefixLen() got = %v, want 0", got)
+		}
+		if got := CommonPrefixLen([]int{1}, []int{}); got != 0 {
+			t.Errorf("CommonPrefixLen() got = %v, want 0", got)
+		}
+	})
+}
+
+func TestCommonSuffixLen(t *testing.T) {
+	t.Run("counts matching trailing elements", func(t *testing.T) {
+		a := []int{1, 2, 3, 4}
+		b := []int{9, 8, 3, 4}
+		if got := CommonSuffixLen(a, b); got != 2 {
+			t.Errorf("CommonSuffixLen() got = %v, want 2", got)
+		}
+	})
+
+	t.Run("aligns slices of different lengths at the end", func(t *testing.T) {
+		a := []string{"x", "b", "c"}
+		b := []string{"c"}
+		if got := CommonSuffixLen(a, b); got != 1 {
+			t.Errorf("CommonSuffixLen() got = %v, want 1", got)
+		}
+	})
+
+	t.Run("returns full length for identical slices", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		if got := CommonSuffixLen(a, []int{1, 2, 3}); got != 3 {
+			t.Errorf("CommonSuffixLen() got = %v, want 3", got)
+		}
+	})
+
+	t.Run("returns 0 for nil or empty input", func(t *testing.T) {
+		if got := CommonSuffixLen([]int{1}, nil); got != 0 {
+			t.Errorf("CommonSuffixLen() got = %v, want 0", got)
+		}
+	})
+}
+
+func TestDiffEdits(t *testing.T) {
+	// reconstruct replays an edit script, checking that it consumes before exactly.
+	reconstruct := func(t *testing.T, before []string, edits []Edit[string]) []string {
+		t.Helper()
+		result := []string{}
+		position := 0
+		for _, edit := range edits {
+			switch edit.Op {
+			case EditKeep, EditDelete:
+				if position >= len(before) || before[position] != edit.Value {
+					t.Fatalf("DiffEdits() %v %q does not match old element at %d", edit.Op, edit.Value, position)
+				}
+				position++
+				if edit.Op == EditKeep {
+					result = append(result, edit.Value)
+				}
+			case EditInsert:
+				result = append(result, edit.Value)
+			}
+		}
+		if position != len(before) {
+			t.Fatalf("DiffEdits() consumed %d of %d old elements", position, len(before))
+		}
+		return result
+	}
+
+	t.Run("produces keeps, deletions and insertions", func(t *testing.T) {
+		before := []string{"a", "b", "c", "d", "e"}
+		after := []string{"a", "c", "x", "d", "e", "f"}
+		expected := []Edit[string]{
+			{EditKeep, "a"},
+			{EditDelete, "b"},
+			{EditKeep, "c"},
+			{EditInsert, "x"},
+			{EditKeep, "d"},
+			{EditKeep, "e"},
+			{EditInsert, "f"},
+		}
+		result := DiffEdits(before, after)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("DiffEdits() got = %v, want %v", result, expected)
+		}
+		if got := reconstruct(t, before, result); !reflect.DeepEqual(got, after) {
+			t.Errorf("applying DiffEdits() got = %v, want %v", got, after)
+		}
+	})
+
+	t.Run("produces a minimal script that reconstructs the new slice", func(t *testing.T) {
+		cases := []struct {
+			before, after []string
+			lcs           int
+		}{
+			{before: []string{"x", "y", "z"}, after: []string{"z", "y", "x"}, lcs: 1},
+			{before: []string{"a", "b", "a", "b"}, after: []string{"b", "a", "b", "a"}, lcs: 3},
+			{before: []string{}, after: []string{"a", "b"}, lcs: 0},
+			{before: []string{"a", "b"}, after: nil, lcs: 0},
+			{before: []string{"same"}, after: []string{"same"}, lcs: 1},
+		}
+		for _, tc := range cases {
+			edits := DiffEdits(tc.before, tc.after)
+			if got := reconstruct(t, tc.before, edits); !reflect.DeepEqual(got, append([]string{}, tc.after...)) {
+				t.Errorf("applying DiffEdits(%v, %v) got = %v, want %v", tc.before, tc.after, got, tc.after)
+			}
+			// A minimal script keeps exactly the longest common subsequence.
+			keeps := 0
+			for _, edit := range edits {
+				if edit.Op == EditKeep {
+					keeps++
+				}
+			}
+			if keeps != tc.lcs {
+				t.Errorf("DiffEdits(%v, %v) kept %d elements, want %d", tc.before, tc.after, keeps, tc.lcs)
+			}
+		}
+	})
+
+	t.Run("returns nil when both inputs are empty", func(t *testing.T) {
+		if result := DiffEdits[[]int](nil, []int{}); result != nil {
+			t.Errorf("DiffEdits() on empty inputs should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestEditOpString(t *testing.T) {
+	cases := map[EditOp]string{EditKeep: "Keep", EditInsert: "Insert", EditDelete: "Delete", EditOp(9): "EditOp(9)"}
+	for op, expected := range cases {
+		if got := op.String(); got != expected {
+			t.Errorf("EditOp.String() got = %v, want %v", got, expected)
+		}
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	t.Run("round-trips DiffEdits across several examples", func(t *testing.T) {
+		cases := [][2][]int{
+			{{1, 2, 3, 4, 5}, {1, 3, 6, 4, 5, 7}},
+			{{1, 2, 3}, {3, 2, 1}},
+			{{}, {1, 2}},
+			{{1, 2}, {}},
+			{{4, 4, 4}, {4, 4}},
+			{{1, 2, 3}, {1, 2, 3}},
+		}
+		for _, tc := range cases {
+			before, after := tc[0], tc[1]
+			result, err := ApplyEdits(before, DiffEdits(before, after))
+			if err != nil {
+				t.Fatalf("ApplyEdits() unexpected error for %v -> %v: %v", before, after, err)
+			}
+			if len(result) != len(after) || (len(after) > 0 && !reflect.DeepEqual(result, after)) {
+				t.Errorf("ApplyEdits() got = %v, want %v", result, after)
+			}
+		}
+	})
+
+	t.Run("returns error when a delete does not match the current element", func(t *testing.T) {
+		edits := []Edit[int]{{EditKeep, 1}, {EditDelete, 9}}
+		result, err := ApplyEdits([]int{1, 2}, edits)
+		if !errors.Is(err, ErrInvalidEdits) {
+			t.Errorf("ApplyEdits() error got = %v, want %v", err, ErrInvalidEdits)
+		}
+		if result != nil {
+			t.Errorf("ApplyEdits() on error should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns error when the script runs past the end", func(t *testing.T) {
+		edits := []Edit[int]{{EditKeep, 1}, {EditKeep, 2}}
+		if _, err := ApplyEdits([]int{1}, edits); !errors.Is(err, ErrInvalidEdits) {
+			t.Errorf("ApplyEdits() error got = %v, want %v", err, ErrInvalidEdits)
+		}
+	})
+
+	t.Run("returns error when elements are left unconsumed", func(t *testing.T) {
+		edits := []Edit[int]{{EditKeep, 1}}
+		if _, err := ApplyEdits([]int{1, 2}, edits); !errors.Is(err, ErrInvalidEdits) {
+			t.Errorf("ApplyEdits() error got = %v, want %v", err, ErrInvalidEdits)
+		}
+	})
+
+	t.Run("returns error for an unknown operation", func(t *testing.T) {
+		edits := []Edit[int]{{EditOp(42), 1}}
+		if _, err := ApplyEdits([]int{1}, edits); !errors.Is(err, ErrInvalidEdits) {
+			t.Errorf("ApplyEdits() error got = %v, want %v", err, ErrInvalidEdits)
+		}
+	})
+
+	t.Run("returns nil for empty inputs", func(t *testing.T) {
+		result, err := ApplyEdits[int](nil, nil)
+		if result != nil || err != nil {
+			t.Errorf("ApplyEdits() on empty inputs got = (%v, %v), want (nil, nil)", result, err)
+		}
+	})
+}
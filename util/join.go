@@ -0,0 +1,119 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "cmp"
+
+// InnerJoin joins two slices on a key, like a SQL inner join. For every pair of elements
+// (x from a, y from b) whose keys are equal, it appends combine(x, y) to the result, so a
+// key shared by m elements of a and n elements of b yields m*n rows.
+// Rows are ordered by the position of x in a, then by the position of y in b.
+// It returns nil if either slice is nil.
+func InnerJoin[A, B, R any, K comparable](
+	a []A,
+	b []B,
+	keyA func(A) K,
+	keyB func(B) K,
+	combine func(x A, y B) R,
+) []R {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	index := indexByKey(b, keyB)
+	result := []R{}
+	for _, x := range a {
+		for _, position := range index[keyA(x)] {
+			result = append(result, combine(x, b[position]))
+		}
+	}
+	return result
+}
+
+// MergeJoin joins two slices on a key like InnerJoin, but uses a merge join instead of a
+// hash map. Both a and b must already be sorted in ascending order of their keys; the
+// inputs are not checked, and unsorted inputs silently miss matches. In exchange, it runs
+// in O(n+m) time plus the size of the output and allocates nothing beyond the result.
+// For sorted inputs it returns the same rows in the same order as InnerJoin: a key shared
+// by m elements of a and n elements of b yields m*n rows, ordered by the position of x in
+// a, then by the position of y in b. It returns nil if either slice is nil.
+func MergeJoin[A, B, R any, K cmp.Ordered](
+	a []A,
+	b []B,
+	keyA func(A) K,
+	keyB func(B) K,
+	combine func(x A, y B) R,
+) []R {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	result := []R{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		key := keyA(a[i])
+		switch cmp.Compare(key, keyB(b[j])) {
+		case -1:
+			i++
+			continue
+		case 1:
+			j++
+			continue
+		}
+
+		// Find the run of equal keys in b, then pair it with every matching element of a.
+		runEnd := j + 1
+		for runEnd < len(b) && cmp.Compare(keyB(b[runEnd]), key) == 0 {
+			runEnd++
+		}
+		for ; i < len(a) && cmp.Compare(keyA(a[i]), key) == 0; i++ {
+			for _, y := range b[j:runEnd] {
+				result = append(result, combine(a[i], y))
+			}
+		}
+		j = runEnd
+	}
+	return result
+}
+
+// LeftJoin joins two slices on a key, like a SQL left outer join. Every element x of a
+// produces at least one row: combine(x, &y) for each element y of b with an equal key, or
+// combine(x, nil) when there is no match. The pointer refers to the element inside b, so
+// combine must not retain it if b may change.
+// Rows are ordered by the position of x in a, then by the position of y in b. A nil b is
+// treated as empty. It returns nil if a is nil.
+func LeftJoin[A, B, R any, K comparable](
+	a []A,
+	b []B,
+	keyA func(A) K,
+	keyB func(B) K,
+	combine func(x A, y *B) R,
+) []R {
+	if a == nil {
+		return nil
+	}
+
+	index := indexByKey(b, keyB)
+	result := make([]R, 0, len(a))
+	for _, x := range a {
+		positions := index[keyA(x)]
+		if len(positions) == 0 {
+			result = append(result, combine(x, nil))
+			continue
+		}
+		for _, position := range positions {
+			result = append(result, combine(x, &b[position]))
+		}
+	}
+	return result
+}
+
+// indexByKey maps each key to the positions in collection of the elements that produce it,
+// in ascending order.
+func indexByKey[E any, K comparable](collection []E, key func(E) K) map[K][]int {
+	index := make(map[K][]int, len(collection))
+	for i, item := range collection {
+		k := key(item)
+		index[k] = append(index[k], i)
+	}
+	return index
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+type joinCustomer struct {
+	ID   int
+	Name string
+}
+
+type joinOrder struct {
+	CustomerID int
+	Item       string
+}
+
+func customerID(c joinCustomer) int { return c.ID }
+
+func orderCustomerID(o joinOrder) int { return o.CustomerID }
+
+func TestInnerJoin(t *testing.T) {
+	customers := []joinCustomer{{1, "ann"}, {2, "bob"}, {3, "cid"}}
+	orders := []joinOrder{{1, "pen"}, {2, "ink"}, {1, "pad"}, {4, "cup"}}
+	combine := func(c joinCustomer, o joinOrder) string { return c.Name + ":" + o.Item }
+
+	t.Run("produces one row per matching pair in a one-to-many join", func(t *testing.T) {
+		expected := []string{"ann:pen", "ann:pad", "bob:ink"}
+		result := InnerJoin(customers, orders, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("InnerJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("produces the cartesian product within a key group", func(t *testing.T) {
+		dupes := []joinCustomer{{1, "ann"}, {1, "amy"}}
+		expected := []string{"ann:pen", "ann:pad", "amy:pen", "amy:pad"}
+		result := InnerJoin(dupes, orders, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("InnerJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when nothing matches", func(t *testing.T) {
+		expected := []string{}
+		result := InnerJoin(customers, []joinOrder{}, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("InnerJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil when either input is nil", func(t *testing.T) {
+		if result := InnerJoin(nil, orders, customerID, orderCustomerID, combine); result != nil {
+			t.Errorf("InnerJoin() with nil left should return nil, but got %v", result)
+		}
+		if result := InnerJoin(customers, nil, customerID, orderCustomerID, combine); result != nil {
+			t.Errorf("InnerJoin() with nil right should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMergeJoin(t *testing.T) {
+	customers := []joinCustomer{{1, "ann"}, {2, "bob"}, {2, "bea"}, {3, "cid"}, {5, "eve"}}
+	orders := []joinOrder{{0, "mug"}, {1, "pen"}, {1, "pad"}, {2, "ink"}, {2, "cap"}, {4, "cup"}, {5, "hat"}}
+	combine := func(c joinCustomer, o joinOrder) string { return c.Name + ":" + o.Item }
+
+	t.Run("joins sorted inputs with multiple matches per key", func(t *testing.T) {
+		expected := []string{"ann:pen", "ann:pad", "bob:ink", "bob:cap", "bea:ink", "bea:cap", "eve:hat"}
+		result := MergeJoin(customers, orders, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("matches the hash join reference", func(t *testing.T) {
+		expected := InnerJoin(customers, orders, customerID, orderCustomerID, combine)
+		result := MergeJoin(customers, orders, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeJoin() got = %v, want InnerJoin() = %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when no keys match", func(t *testing.T) {
+		result := MergeJoin(customers[:1], orders[3:4], customerID, orderCustomerID, combine)
+		if result == nil || len(result) != 0 {
+			t.Errorf("MergeJoin() should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil if either input is nil", func(t *testing.T) {
+		if result := MergeJoin(nil, orders, customerID, orderCustomerID, combine); result != nil {
+			t.Errorf("MergeJoin() with nil a should return nil, but got %v", result)
+		}
+		if result := MergeJoin(customers, nil, customerID, orderCustomerID, combine); result != nil {
+			t.Errorf("MergeJoin() with nil b should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestLeftJoin(t *testing.T) {
+	customers := []joinCustomer{{1, "ann"}, {2, "bob"}, {3, "cid"}}
+	orders := []joinOrder{{1, "pen"}, {1, "pad"}, {2, "ink"}}
+	combine := func(c joinCustomer, o *joinOrder) string {
+		if o == nil {
+			return c.Name + ":-"
+		}
+		return c.Name + ":" + o.Item
+	}
+
+	t.Run("emits unmatched left elements with a nil right pointer", func(t *testing.T) {
+		expected := []string{"ann:pen", "ann:pad", "bob:ink", "cid:-"}
+		result := LeftJoin(customers, orders, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("LeftJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("points at the matched element in the right slice", func(t *testing.T) {
+		var matched *joinOrder
+		LeftJoin(customers[1:2], orders, customerID, orderCustomerID, func(_ joinCustomer, o *joinOrder) int {
+			matched = o
+			return 0
+		})
+		if matched != &orders[2] {
+			t.Errorf("LeftJoin() right pointer should refer to orders[2]")
+		}
+	})
+
+	t.Run("treats a nil right slice as empty", func(t *testing.T) {
+		expected := []string{"ann:-", "bob:-", "cid:-"}
+		result := LeftJoin(customers, nil, customerID, orderCustomerID, combine)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("LeftJoin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil left input", func(t *testing.T) {
+		result := LeftJoin(nil, orders, customerID, orderCustomerID, combine)
+		if result != nil {
+			t.Errorf("LeftJoin() with nil left should return nil, but got %v", result)
+		}
+	})
+}
@@ -2,14 +2,23 @@
 package util
 
 import (
+	"cmp"
+	"container/heap"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	mathrand "math/rand"
 	"slices"
 )
 
 // readRandom is an indirection for crypto/rand.Read to enable testing error paths.
 var readRandom = rand.Read
 
+// errInvalidRandRange is returned by randIndex when asked for an empty range.
+var errInvalidRandRange = errors.New("util: random index range must be at least 1")
+
 // MapReduce combines Map and Reduce operations in a single pass.
 // It applies a mapping function to each element of a slice and then reduces the results
 // to a single value using a reducer function.
@@ -64,6 +73,101 @@ func FindLast[S ~[]E, E any](collection S, predicate func(item E, index int) boo
 	return zero, false
 }
 
+// FindPtr returns a pointer to the first element in a slice that satisfies a predicate
+// function, or nil if no element matches.
+//
+// The pointer refers to the element inside the slice's backing array, so writing through
+// it intentionally changes the caller's slice. This is an exception to the package's
+// no-mutation rule for callers that need to update a found element in place.
+func FindPtr[S ~[]E, E any](collection S, predicate func(item E, index int) bool) *E {
+	for i := range collection {
+		if predicate(collection[i], i) {
+			return &collection[i]
+		}
+	}
+	return nil
+}
+
+// MaxByValue returns the element whose selected value is the largest, together with that
+// value and a boolean indicating whether the slice was non-empty. The selector is called
+// once per element. On ties, the first such element is returned.
+func MaxByValue[S ~[]E, E any, N cmp.Ordered](collection S, selector func(item E) N) (E, N, bool) {
+	return extremeByValue(collection, selector, func(candidate, best N) bool { return candidate > best })
+}
+
+// MinByValue returns the element whose selected value is the smallest, together with that
+// value and a boolean indicating whether the slice was non-empty. The selector is called
+// once per element. On ties, the first such element is returned.
+func MinByValue[S ~[]E, E any, N cmp.Ordered](collection S, selector func(item E) N) (E, N, bool) {
+	return extremeByValue(collection, selector, func(candidate, best N) bool { return candidate < best })
+}
+
+// extremeByValue implements MaxByValue and MinByValue.
+func extremeByValue[S ~[]E, E any, N cmp.Ordered](
+	collection S,
+	selector func(item E) N,
+	better func(candidate, best N) bool,
+) (E, N, bool) {
+	var zeroE E
+	var zeroN N
+	if len(collection) == 0 {
+		return zeroE, zeroN, false
+	}
+
+	bestItem, bestValue := collection[0], selector(collection[0])
+	for _, item := range collection[1:] {
+		if value := selector(item); better(value, bestValue) {
+			bestItem, bestValue = item, value
+		}
+	}
+	return bestItem, bestValue, true
+}
+
+// ArgMax returns the index of the largest element and a boolean indicating whether the
+// slice was non-empty. On ties, the index of the first such element is returned. For nil
+// or empty input it returns -1 and false.
+func ArgMax[E cmp.Ordered](collection []E) (int, bool) {
+	return ArgMaxBy(collection, func(item E) E { return item })
+}
+
+// ArgMin returns the index of the smallest element and a boolean indicating whether the
+// slice was non-empty. On ties, the index of the first such element is returned. For nil
+// or empty input it returns -1 and false.
+func ArgMin[E cmp.Ordered](collection []E) (int, bool) {
+	return ArgMinBy(collection, func(item E) E { return item })
+}
+
+// ArgMaxBy is like ArgMax but compares the values returned by selector, which is called
+// once per element.
+func ArgMaxBy[S ~[]E, E any, N cmp.Ordered](collection S, selector func(item E) N) (int, bool) {
+	return argExtremeBy(collection, selector, func(candidate, best N) bool { return candidate > best })
+}
+
+// ArgMinBy is like ArgMin but compares the values returned by selector, which is called
+// once per element.
+func ArgMinBy[S ~[]E, E any, N cmp.Ordered](collection S, selector func(item E) N) (int, bool) {
+	return argExtremeBy(collection, selector, func(candidate, best N) bool { return candidate < best })
+}
+
+// argExtremeBy implements ArgMaxBy and ArgMinBy.
+func argExtremeBy[S ~[]E, E any, N cmp.Ordered](
+	collection S,
+	selector func(item E) N,
+	better func(candidate, best N) bool,
+) (int, bool) {
+	if len(collection) == 0 {
+		return -1, false
+	}
+
+	bestIndex, bestValue := 0, selector(collection[0])
+	for i := 1; i < len(collection); i++ {
+		if value := selector(collection[i]); better(value, bestValue) {
+			bestIndex, bestValue = i, value
+		}
+	}
+	return bestIndex, true
+}
+
 // Partition divides a slice into two slices based on a predicate function.
 // The first returned slice contains all elements that satisfy the predicate,
 // and the second contains all elements that don't.
@@ -92,6 +196,46 @@ func Partition[S ~[]E, E any](collection S, predicate func(item E, index int) bo
 	return matched, unmatched
 }
 
+// PartitionByKey routes each element of a slice to the bucket identified by keySelector,
+// generalizing Partition to any number of buckets. It is like GroupBy but the selector also
+// receives the element's index. Elements keep their original relative order within each
+// bucket. It returns nil for nil input and an empty (non-nil) map for empty input.
+func PartitionByKey[S ~[]E, E any, K comparable](collection S, keySelector func(item E, index int) K) map[K]S {
+	if collection == nil {
+		return nil
+	}
+
+	result := make(map[K]S)
+	for i, item := range collection {
+		key := keySelector(item, i)
+		result[key] = append(result[key], item)
+	}
+	return result
+}
+
+// Classify assigns each element the label of the first rule whose predicate matches it,
+// or fallback if no rule matches. Rules are tried in order, so earlier rules take
+// priority, which makes it a small rules-engine primitive for routing. Each rule pairs a
+// predicate (First) with its label (Second). The result has one label per element, in
+// order. It returns nil for nil input and an empty (non-nil) slice for empty input.
+func Classify[S ~[]E, E any, K any](collection S, rules []Pair[func(item E) bool, K], fallback K) []K {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]K, len(collection))
+	for i, item := range collection {
+		result[i] = fallback
+		for _, rule := range rules {
+			if rule.First(item) {
+				result[i] = rule.Second
+				break
+			}
+		}
+	}
+	return result
+}
+
 // Zip combines elements from two slices into a slice of pairs.
 // The length of the result is the minimum of the lengths of the two input slices.
 // Each pair is represented as a [2]any array where the first element is from the first slice
@@ -119,6 +263,22 @@ func Zip[S1 ~[]E1, E1 any, S2 ~[]E2, E2 any](slice1 S1, slice2 S2) [][2]any {
 	return result
 }
 
+// MapZip applies f to the elements at each index of two parallel slices and returns the
+// results, combining Zip and Map in one call without intermediate pairs. Like Zip, it
+// stops at the shorter of the two slices; f also receives the index. It returns nil if
+// either slice is nil and an empty (non-nil) slice if either is empty.
+func MapZip[A, B, R any](a []A, b []B, f func(x A, y B, index int) R) []R {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	result := make([]R, min(len(a), len(b)))
+	for i := range result {
+		result[i] = f(a[i], b[i], i)
+	}
+	return result
+}
+
 // ZipWithIndex pairs each element in a slice with its index.
 // Each pair is represented as a [2]any array where the first element is the original element
 // and the second element is its index.
@@ -139,11 +299,97 @@ func ZipWithIndex[S ~[]E, E any](collection S) [][2]any {
 	return result
 }
 
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// ZipLongest combines elements from two slices into a slice of pairs whose length is the
+// maximum of the lengths of the two input slices. Missing slots on the shorter side are
+// filled with the zero value of its element type.
+// If both slices are nil, it returns nil. If only one is nil, it is treated as empty and
+// padded with zero values.
+func ZipLongest[A, B any](a []A, b []B) []Pair[A, B] {
+	if a == nil && b == nil {
+		return nil
+	}
+
+	result := make([]Pair[A, B], max(len(a), len(b)))
+	for i := range result {
+		if i < len(a) {
+			result[i].First = a[i]
+		}
+		if i < len(b) {
+			result[i].Second = b[i]
+		}
+	}
+	return result
+}
+
+// ZipConst pairs each element of a slice with the same constant value, which is useful for
+// broadcasting a value such as a batch ID alongside every record.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func ZipConst[E, C any](collection []E, constant C) []Pair[E, C] {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]Pair[E, C], len(collection))
+	for i, item := range collection {
+		result[i] = Pair[E, C]{First: item, Second: constant}
+	}
+	return result
+}
+
+// ZipToMap builds a map from two parallel slices, using elements of keys as map keys and
+// the elements of values at the same index as map values. Like Zip, it stops at the
+// shorter of the two slices. If a key occurs more than once, the last value wins.
+// It returns nil if either slice is nil and an empty (non-nil) map if either is empty.
+func ZipToMap[K comparable, V any](keys []K, values []V) map[K]V {
+	if keys == nil || values == nil {
+		return nil
+	}
+
+	length := min(len(keys), len(values))
+	result := make(map[K]V, length)
+	for i := range length {
+		result[keys[i]] = values[i]
+	}
+	return result
+}
+
+// MergePairs merges layers of key/value pairs, such as defaults followed by overrides.
+// When a key appears more than once, the value from the latest occurrence wins, while the
+// key keeps the position of its first appearance across all layers.
+// It returns nil when no layers are given.
+func MergePairs[K comparable, V any](layers ...[]Pair[K, V]) []Pair[K, V] {
+	if len(layers) == 0 {
+		return nil
+	}
+
+	positions := make(map[K]int)
+	result := []Pair[K, V]{}
+	for _, layer := range layers {
+		for _, pair := range layer {
+			if position, exists := positions[pair.First]; exists {
+				result[position].Second = pair.Second
+				continue
+			}
+			positions[pair.First] = len(result)
+			result = append(result, pair)
+		}
+	}
+	return result
+}
+
 // Shuffle returns a new slice with the elements randomly reordered.
 // It uses crypto/rand for secure random number generation.
 //
 // This function uses a cryptographically secure random number generator
 // and is suitable for both general-purpose and security-sensitive operations.
+// Random indices are drawn with rejection sampling, so every permutation is
+// equally likely. If the random source fails, an unshuffled clone is returned.
 func Shuffle[S ~[]E, E any](collection S) S {
 	if collection == nil {
 		return nil
@@ -159,40 +405,200 @@ func Shuffle[S ~[]E, E any](collection S) S {
 
 	// Fisher-Yates shuffle algorithm with crypto/rand
 	for i := length - 1; i > 0; i-- {
-		// Generate a random number in the range [0, i]
-		// We only need enough random bytes to cover the range [0, i]
-		maxBytes := 1
-		if i > 255 {
-			maxBytes = 2 // 2 bytes for i > 255
-		}
-		if i > 65535 {
-			maxBytes = 4 // 4 bytes for i > 65535
+		// Generate a uniformly distributed random number in the range [0, i]
+		j, err := randIndex(i + 1)
+		if err != nil {
+			// In case of error, return the unshuffled clone
+			return slices.Clone(collection)
 		}
 
-		randomBytes := make([]byte, maxBytes)
-		_, err := readRandom(randomBytes)
+		// Swap elements
+		result[i], result[j] = result[j], result[i]
+	}
+
+	return result
+}
+
+// ShuffleSeeded returns a new slice with the elements reordered by a pseudo-random
+// permutation derived from seed. Unlike Shuffle it uses a math/rand source, so the same
+// seed and length always yield the same order, which makes it suitable for consistent
+// sharding and reproducible tests but not for anything security-sensitive.
+func ShuffleSeeded[S ~[]E, E any](collection S, seed int64) S {
+	if collection == nil {
+		return nil
+	}
+
+	result := slices.Clone(collection)
+	if len(result) <= 1 {
+		return result
+	}
+
+	//nolint:gosec // A reproducible, seeded permutation is the purpose of this function.
+	source := mathrand.New(mathrand.NewSource(seed))
+	source.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result
+}
+
+// SampleWhere returns up to n distinct elements, chosen uniformly at random without
+// replacement, from the elements that satisfy the predicate. It uses crypto/rand, and the
+// sampled elements are returned in random order. If fewer than n elements match, all of
+// them are returned in random order.
+//
+// If the random source fails, the first n matching elements are returned in their original
+// order instead. It returns nil for nil input and an empty (non-nil) slice when n < 1 or
+// nothing matches.
+func SampleWhere[S ~[]E, E any](collection S, n int, predicate func(item E, index int) bool) S {
+	if collection == nil {
+		return nil
+	}
+
+	matches := Filter(collection, predicate)
+	count := min(max(n, 0), len(matches))
+	if count == 0 {
+		return S{}
+	}
+
+	// Partial Fisher-Yates: only the first count positions need to be drawn
+	result := slices.Clone(matches)
+	for i := range count {
+		offset, err := randIndex(len(result) - i)
 		if err != nil {
-			// In case of error, return the unshuffled clone
-			return result
+			return slices.Clone(matches[:count])
 		}
+		j := i + offset
+		result[i], result[j] = result[j], result[i]
+	}
+	return result[:count:count]
+}
+
+// WeightedSampleN returns up to n distinct elements sampled without replacement, where each
+// draw picks an element with probability proportional to its weight. It implements the
+// A-Res reservoir algorithm of Efraimidis and Spirakis in one pass using crypto/rand:
+// every element gets the key u^(1/weight) for a uniform random u, and the n largest keys
+// win. The weight function is called once per element, and elements with a non-positive
+// or NaN weight are never sampled. The sample is ordered by descending key, which is the
+// order the elements would have been drawn in one at a time.
+//
+// If the random source fails, the first n eligible elements are returned in their original
+// order instead. It returns nil for nil input and an empty (non-nil) slice when n < 1 or no
+// element has a positive weight.
+func WeightedSampleN[S ~[]E, E any](collection S, n int, weight func(item E) float64) S {
+	if collection == nil {
+		return nil
+	}
+	if n < 1 {
+		return S{}
+	}
 
-		// Convert bytes to an integer and reduce to the range [0, i]
-		var randomInt int
-		switch maxBytes {
-		case 1:
-			randomInt = int(randomBytes[0]) % (i + 1)
-		case 2:
-			randomInt = int(binary.BigEndian.Uint16(randomBytes)) % (i + 1)
-		case 4:
-			// This is safe because we're only using 4 bytes (uint32) which fits in int on all platforms
-			randomInt = int(binary.BigEndian.Uint32(randomBytes)) % (i + 1)
+	eligible := make(S, 0, len(collection))
+	reservoir := make(weightedReservoir[E], 0, min(n, len(collection)))
+	var randErr error
+	for _, item := range collection {
+		w := weight(item)
+		if !(w > 0) {
+			continue
+		}
+		eligible = append(eligible, item)
+		if randErr != nil {
+			continue
 		}
 
-		j := randomInt
+		u, err := randUnitFloat()
+		if err != nil {
+			randErr = err
+			continue
+		}
+		// log(u)/w orders elements exactly like u^(1/w) but avoids underflow for small weights.
+		key := math.Log(u) / w
+		switch {
+		case len(reservoir) < n:
+			heap.Push(&reservoir, weightedItem[E]{item: item, key: key})
+		case key > reservoir[0].key:
+			reservoir[0] = weightedItem[E]{item: item, key: key}
+			heap.Fix(&reservoir, 0)
+		}
+	}
 
-		// Swap elements
-		result[i], result[j] = result[j], result[i]
+	if randErr != nil {
+		return eligible[:min(n, len(eligible)):min(n, len(eligible))]
 	}
 
+	slices.SortFunc(reservoir, func(a, b weightedItem[E]) int { return cmp.Compare(b.key, a.key) })
+	result := make(S, len(reservoir))
+	for i, entry := range reservoir {
+		result[i] = entry.item
+	}
 	return result
 }
+
+// weightedItem is an element of a WeightedSampleN reservoir together with its A-Res key.
+type weightedItem[E any] struct {
+	item E
+	key  float64
+}
+
+// weightedReservoir is a min-heap of weightedItem by key, implementing heap.Interface, so
+// the element with the smallest key is the first to be replaced.
+type weightedReservoir[E any] []weightedItem[E]
+
+func (r weightedReservoir[E]) Len() int           { return len(r) }
+func (r weightedReservoir[E]) Less(i, j int) bool { return r[i].key < r[j].key }
+func (r weightedReservoir[E]) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+
+func (r *weightedReservoir[E]) Push(x any) {
+	*r = append(*r, x.(weightedItem[E])) //nolint:forcetypeassert // heap only passes values given to heap.Push.
+}
+
+func (r *weightedReservoir[E]) Pop() any {
+	old := *r
+	last := old[len(old)-1]
+	*r = old[:len(old)-1]
+	return last
+}
+
+// randUnitFloat returns a uniformly distributed random float64 in the range (0, 1] using
+// crypto/rand, built from 53 random bits so every value is exactly representable.
+func randUnitFloat() (float64, error) {
+	var buf [8]byte
+	if _, err := readRandom(buf[:]); err != nil {
+		return 0, err
+	}
+	return float64(binary.BigEndian.Uint64(buf[:])>>11+1) / (1 << 53), nil
+}
+
+// randIndex returns a uniformly distributed random integer in the range [0, n)
+// using crypto/rand. It reads only as many bytes as are needed to represent n-1,
+// masks the value down to the bit length of n-1, and rejects values that fall
+// outside the range, so no index is favored over another.
+//
+// It returns an error if n is less than 1 or the random source fails.
+// For n == 1 it returns 0 without consuming any randomness.
+func randIndex(n int) (int, error) {
+	if n < 1 {
+		return 0, errInvalidRandRange
+	}
+	if n == 1 {
+		return 0, nil
+	}
+
+	maxValue := uint64(n - 1)
+	bitLen := bits.Len64(maxValue)
+	byteLen := (bitLen + 7) / 8
+	mask := uint64(1)<<bitLen - 1
+
+	// Only the trailing byteLen bytes are filled, so the leading bytes stay zero.
+	buf := make([]byte, 8)
+	for {
+		if _, err := readRandom(buf[8-byteLen:]); err != nil {
+			return 0, err
+		}
+
+		value := binary.BigEndian.Uint64(buf) & mask
+		if value <= maxValue {
+			// This is safe because value <= n-1, which is an int
+			return int(value), nil
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package util
 
 import (
+	"math"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -159,6 +161,129 @@ func TestFindLast(t *testing.T) {
 	})
 }
 
+func TestFindPtr(t *testing.T) {
+	type Item struct {
+		ID    int
+		Count int
+	}
+
+	t.Run("mutating through the pointer changes the original slice", func(t *testing.T) {
+		input := []Item{{ID: 1}, {ID: 2}, {ID: 3}}
+		ptr := FindPtr(input, func(item Item, _ int) bool { return item.ID == 2 })
+		if ptr == nil {
+			t.Fatalf("FindPtr() should have found ID 2")
+		}
+		ptr.Count = 10
+		if input[1].Count != 10 {
+			t.Errorf("FindPtr() pointer should alias the slice, got input[1].Count = %v", input[1].Count)
+		}
+	})
+
+	t.Run("returns pointer to the first match", func(t *testing.T) {
+		input := []int{1, 4, 6}
+		ptr := FindPtr(input, func(item int, _ int) bool { return item%2 == 0 })
+		if ptr != &input[1] {
+			t.Errorf("FindPtr() should point at input[1]")
+		}
+	})
+
+	t.Run("returns nil when no element matches", func(t *testing.T) {
+		input := []int{1, 3, 5}
+		ptr := FindPtr(input, func(item int, _ int) bool { return item%2 == 0 })
+		if ptr != nil {
+			t.Errorf("FindPtr() should return nil when nothing matches, but got %v", *ptr)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		ptr := FindPtr(input, func(_ int, _ int) bool { return true })
+		if ptr != nil {
+			t.Errorf("FindPtr() on nil slice should return nil")
+		}
+	})
+}
+
+func TestMaxMinByValue(t *testing.T) {
+	type Player struct {
+		Name  string
+		Score float64
+	}
+	score := func(p Player) float64 { return p.Score }
+	input := []Player{{"ann", 7.5}, {"bob", 9.25}, {"cid", 3}, {"dee", 9.25}}
+
+	t.Run("returns the element with the largest value and the value", func(t *testing.T) {
+		item, value, found := MaxByValue(input, score)
+		if !found || item.Name != "bob" || value != 9.25 {
+			t.Errorf("MaxByValue() got = (%v, %v, %v), want ({bob 9.25}, 9.25, true)", item, value, found)
+		}
+	})
+
+	t.Run("returns the element with the smallest value and the value", func(t *testing.T) {
+		item, value, found := MinByValue(input, score)
+		if !found || item.Name != "cid" || value != 3 {
+			t.Errorf("MinByValue() got = (%v, %v, %v), want ({cid 3}, 3, true)", item, value, found)
+		}
+	})
+
+	t.Run("returns zero values for empty and nil input", func(t *testing.T) {
+		var empty []Player
+		if item, value, found := MaxByValue(empty, score); found || item != (Player{}) || value != 0 {
+			t.Errorf("MaxByValue() on nil slice got = (%v, %v, %v), want zero values", item, value, found)
+		}
+		if item, value, found := MinByValue([]Player{}, score); found || item != (Player{}) || value != 0 {
+			t.Errorf("MinByValue() on empty slice got = (%v, %v, %v), want zero values", item, value, found)
+		}
+	})
+}
+
+func TestArgMinMax(t *testing.T) {
+	t.Run("returns indices of the extremes", func(t *testing.T) {
+		input := []int{4, 1, 9, 3}
+		if index, found := ArgMax(input); index != 2 || !found {
+			t.Errorf("ArgMax() got = (%v, %v), want (2, true)", index, found)
+		}
+		if index, found := ArgMin(input); index != 1 || !found {
+			t.Errorf("ArgMin() got = (%v, %v), want (1, true)", index, found)
+		}
+	})
+
+	t.Run("returns the first index on ties", func(t *testing.T) {
+		input := []float64{2, 7, 1, 7, 1}
+		if index, _ := ArgMax(input); index != 1 {
+			t.Errorf("ArgMax() got = %v, want 1", index)
+		}
+		if index, _ := ArgMin(input); index != 2 {
+			t.Errorf("ArgMin() got = %v, want 2", index)
+		}
+	})
+
+	t.Run("compares selected values", func(t *testing.T) {
+		words := []string{"kiwi", "banana", "fig", "cherry", "pea"}
+		length := func(item string) int { return len(item) }
+		if index, found := ArgMaxBy(words, length); index != 1 || !found {
+			t.Errorf("ArgMaxBy() got = (%v, %v), want (1, true)", index, found)
+		}
+		if index, found := ArgMinBy(words, length); index != 2 || !found {
+			t.Errorf("ArgMinBy() got = (%v, %v), want (2, true)", index, found)
+		}
+	})
+
+	t.Run("returns not found for empty and nil input", func(t *testing.T) {
+		for _, input := range [][]int{nil, {}} {
+			if index, found := ArgMax(input); index != -1 || found {
+				t.Errorf("ArgMax(%v) got = (%v, %v), want (-1, false)", input, index, found)
+			}
+			if index, found := ArgMin(input); index != -1 || found {
+				t.Errorf("ArgMin(%v) got = (%v, %v), want (-1, false)", input, index, found)
+			}
+			if index, found := ArgMinBy(input, func(item int) int { return item }); index != -1 || found {
+				t.Errorf("ArgMinBy(%v) got = (%v, %v), want (-1, false)", input, index, found)
+			}
+		}
+	})
+}
+
 func TestPartition(t *testing.T) {
 	t.Run("partitions even and odd numbers", func(t *testing.T) {
 		input := []int{1, 2, 3, 4, 5, 6}
@@ -207,6 +332,95 @@ func TestPartition(t *testing.T) {
 	})
 }
 
+func TestPartitionByKey(t *testing.T) {
+	route := func(line string, _ int) string {
+		switch {
+		case strings.HasPrefix(line, "ERROR"):
+			return "error"
+		case strings.HasPrefix(line, "WARN"):
+			return "warn"
+		default:
+			return "other"
+		}
+	}
+
+	t.Run("routes by a computed key preserving order within buckets", func(t *testing.T) {
+		input := []string{"ERROR a", "INFO b", "WARN c", "ERROR d", "DEBUG e"}
+		expected := map[string][]string{
+			"error": {"ERROR a", "ERROR d"},
+			"warn":  {"WARN c"},
+			"other": {"INFO b", "DEBUG e"},
+		}
+		result := PartitionByKey(input, route)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("PartitionByKey() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("passes the index to the selector", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d"}
+		expected := map[bool][]string{true: {"a", "c"}, false: {"b", "d"}}
+		result := PartitionByKey(input, func(_ string, index int) bool { return index%2 == 0 })
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("PartitionByKey() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		result := PartitionByKey([]string{}, route)
+		if result == nil || len(result) != 0 {
+			t.Errorf("PartitionByKey() on empty slice should return empty non-nil map, got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []string
+		result := PartitionByKey(input, route)
+		if result != nil {
+			t.Errorf("PartitionByKey() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestClassify(t *testing.T) {
+	rules := []Pair[func(item int) bool, string]{
+		{First: func(item int) bool { return item < 0 }, Second: "negative"},
+		{First: func(item int) bool { return item%2 == 0 }, Second: "even"},
+		{First: func(item int) bool { return item > 100 }, Second: "large"},
+	}
+
+	t.Run("labels by the first matching rule or the fallback", func(t *testing.T) {
+		// -4 matches the first two rules, 4 only the second, 102 the second and third,
+		// 101 only the third and 7 none.
+		expected := []string{"negative", "even", "even", "large", "other"}
+		result := Classify([]int{-4, 4, 102, 101, 7}, rules, "other")
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Classify() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("uses the fallback when there are no rules", func(t *testing.T) {
+		expected := []string{"other", "other"}
+		result := Classify([]int{1, 2}, nil, "other")
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Classify() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := Classify([]int{}, rules, "other")
+		if result == nil || len(result) != 0 {
+			t.Errorf("Classify() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := Classify([]int(nil), rules, "other"); result != nil {
+			t.Errorf("Classify() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestZip(t *testing.T) {
 	t.Run("zips two slices of same length", func(t *testing.T) {
 		slice1 := []int{1, 2, 3}
@@ -273,6 +487,42 @@ func TestZip(t *testing.T) {
 	})
 }
 
+func TestMapZip(t *testing.T) {
+	weightedSum := func(x int, y float64, index int) float64 { return float64(x)*y + float64(index) }
+
+	t.Run("combines two numeric slices with their index", func(t *testing.T) {
+		expected := []float64{0.5, 3, 9.5}
+		result := MapZip([]int{1, 2, 3}, []float64{0.5, 1, 2.5}, weightedSum)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapZip() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("stops at the shorter slice", func(t *testing.T) {
+		expected := []float64{2, 5}
+		result := MapZip([]int{1, 2, 3, 4}, []float64{2, 2}, weightedSum)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapZip() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice if either slice is empty", func(t *testing.T) {
+		result := MapZip([]int{1}, []float64{}, weightedSum)
+		if result == nil || len(result) != 0 {
+			t.Errorf("MapZip() with an empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil if either slice is nil", func(t *testing.T) {
+		if result := MapZip(nil, []float64{1}, weightedSum); result != nil {
+			t.Errorf("MapZip() with nil a should return nil, but got %v", result)
+		}
+		if result := MapZip([]int{1}, nil, weightedSum); result != nil {
+			t.Errorf("MapZip() with nil b should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestZipWithIndex(t *testing.T) {
 	t.Run("pairs elements with their indices", func(t *testing.T) {
 		input := []string{"a", "b", "c"}
@@ -305,6 +555,161 @@ func TestZipWithIndex(t *testing.T) {
 	})
 }
 
+func TestZipLongest(t *testing.T) {
+	t.Run("pads the shorter second slice with zero values", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		b := []string{"a"}
+		expected := []Pair[int, string]{{1, "a"}, {2, ""}, {3, ""}}
+		result := ZipLongest(a, b)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipLongest() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("pads the shorter first slice with zero values", func(t *testing.T) {
+		a := []int{1}
+		b := []string{"a", "b", "c"}
+		expected := []Pair[int, string]{{1, "a"}, {0, "b"}, {0, "c"}}
+		result := ZipLongest(a, b)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipLongest() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("pads a nil side with zero values", func(t *testing.T) {
+		var a []int
+		b := []string{"a", "b"}
+		expected := []Pair[int, string]{{0, "a"}, {0, "b"}}
+		result := ZipLongest(a, b)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipLongest() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty inputs", func(t *testing.T) {
+		expected := []Pair[int, string]{}
+		result := ZipLongest([]int{}, []string{})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipLongest() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil when both inputs are nil", func(t *testing.T) {
+		result := ZipLongest[int, string](nil, nil)
+		if result != nil {
+			t.Errorf("ZipLongest() on nil slices should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestZipConst(t *testing.T) {
+	t.Run("pairs every element with the constant", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		result := ZipConst(input, 42)
+		if len(result) != len(input) {
+			t.Fatalf("ZipConst() got %d pairs, want %d", len(result), len(input))
+		}
+		for i, pair := range result {
+			if pair.First != input[i] || pair.Second != 42 {
+				t.Errorf("ZipConst() pair %d got = %v, want {%v 42}", i, pair, input[i])
+			}
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := ZipConst([]int{}, "batch")
+		if result == nil || len(result) != 0 {
+			t.Errorf("ZipConst() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := ZipConst([]int(nil), "batch"); result != nil {
+			t.Errorf("ZipConst() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestZipToMap(t *testing.T) {
+	t.Run("zips to the shorter length", func(t *testing.T) {
+		keys := []string{"a", "b", "c"}
+		values := []int{1, 2}
+		expected := map[string]int{"a": 1, "b": 2}
+		result := ZipToMap(keys, values)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipToMap() got = %v, want %v", result, expected)
+		}
+
+		expected = map[string]int{"a": 1}
+		result = ZipToMap(keys[:1], []int{1, 2, 3})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipToMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("resolves duplicate keys to the later value", func(t *testing.T) {
+		keys := []string{"a", "b", "a"}
+		values := []int{1, 2, 3}
+		expected := map[string]int{"a": 3, "b": 2}
+		result := ZipToMap(keys, values)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipToMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		result := ZipToMap([]string{}, []int{1})
+		if result == nil || len(result) != 0 {
+			t.Errorf("ZipToMap() with empty keys should return empty non-nil map, got %v", result)
+		}
+	})
+
+	t.Run("returns nil when either input is nil", func(t *testing.T) {
+		if result := ZipToMap[string, int](nil, []int{1}); result != nil {
+			t.Errorf("ZipToMap() with nil keys should return nil, but got %v", result)
+		}
+		if result := ZipToMap[string, int]([]string{"a"}, nil); result != nil {
+			t.Errorf("ZipToMap() with nil values should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMergePairs(t *testing.T) {
+	t.Run("later layers override values and add keys", func(t *testing.T) {
+		defaults := []Pair[string, int]{{"timeout", 30}, {"retries", 3}}
+		overrides := []Pair[string, int]{{"retries", 5}, {"workers", 8}}
+		expected := []Pair[string, int]{{"timeout", 30}, {"retries", 5}, {"workers", 8}}
+		result := MergePairs(defaults, overrides)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergePairs() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("keeps first-appearance order for keys repeated within a layer", func(t *testing.T) {
+		layer := []Pair[string, int]{{"a", 1}, {"b", 2}, {"a", 3}}
+		expected := []Pair[string, int]{{"a", 3}, {"b", 2}}
+		result := MergePairs(layer)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergePairs() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("does not modify the input layers", func(t *testing.T) {
+		defaults := []Pair[string, int]{{"a", 1}}
+		MergePairs(defaults, []Pair[string, int]{{"a", 2}})
+		if defaults[0].Second != 1 {
+			t.Errorf("MergePairs() modified input layer: got %v", defaults)
+		}
+	})
+
+	t.Run("returns nil when no layers are given", func(t *testing.T) {
+		result := MergePairs[string, int]()
+		if result != nil {
+			t.Errorf("MergePairs() with no layers should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestShuffle(t *testing.T) {
 	// Save and restore readRandom for test isolation
 	origReadRandom := readRandom
@@ -417,4 +822,361 @@ func TestShuffle(t *testing.T) {
 			}
 		}
 	})
+	t.Run("produces a uniform distribution of permutations", func(t *testing.T) {
+		readRandom = origReadRandom
+		const iterations = 60000
+		counts := make(map[[3]int]int)
+		for range iterations {
+			result := Shuffle([]int{0, 1, 2})
+			counts[[3]int{result[0], result[1], result[2]}]++
+		}
+
+		if len(counts) != 6 {
+			t.Fatalf("Shuffle() produced %d distinct permutations, want 6", len(counts))
+		}
+		expected := iterations / 6
+		tolerance := expected / 10
+		for perm, count := range counts {
+			if count < expected-tolerance || count > expected+tolerance {
+				t.Errorf("Shuffle() permutation %v occurred %d times, want %d±%d", perm, count, expected, tolerance)
+			}
+		}
+	})
+
+	t.Run("rejects out-of-range values and re-reads", func(t *testing.T) {
+		// For i=2 values are masked to 2 bits, so 255&3 = 3 is out of range and re-read.
+		values := []byte{255, 4, 1}
+		calls := 0
+		readRandom = func(b []byte) (int, error) {
+			b[0] = values[calls]
+			calls++
+			return 1, nil
+		}
+		input := []string{"a", "b", "c"}
+		expected := []string{"c", "b", "a"}
+		result := Shuffle(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Shuffle() got = %v, want %v", result, expected)
+		}
+		if calls != len(values) {
+			t.Errorf("Shuffle() read random %d times, want %d", calls, len(values))
+		}
+	})
+}
+
+func TestShuffleSeeded(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	t.Run("equal seeds produce equal permutations", func(t *testing.T) {
+		first := ShuffleSeeded(input, 42)
+		second := ShuffleSeeded(input, 42)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("ShuffleSeeded() with equal seeds got = %v and %v, want equal", first, second)
+		}
+		if !SetEqual(first, input) {
+			t.Errorf("ShuffleSeeded() got = %v, want a permutation of %v", first, input)
+		}
+	})
+
+	t.Run("different seeds generally differ", func(t *testing.T) {
+		base := ShuffleSeeded(input, 0)
+		differing := 0
+		for seed := int64(1); seed <= 10; seed++ {
+			if !reflect.DeepEqual(ShuffleSeeded(input, seed), base) {
+				differing++
+			}
+		}
+		if differing == 0 {
+			t.Errorf("ShuffleSeeded() produced the same permutation for every seed: %v", base)
+		}
+	})
+
+	t.Run("does not modify the input", func(t *testing.T) {
+		original := slices.Clone(input)
+		ShuffleSeeded(input, 7)
+		if !reflect.DeepEqual(input, original) {
+			t.Errorf("ShuffleSeeded() modified the input: got %v, want %v", input, original)
+		}
+	})
+
+	t.Run("returns clone for single element and empty slices", func(t *testing.T) {
+		if result := ShuffleSeeded([]int{5}, 1); !reflect.DeepEqual(result, []int{5}) {
+			t.Errorf("ShuffleSeeded() got = %v, want %v", result, []int{5})
+		}
+		if result := ShuffleSeeded([]int{}, 1); result == nil || len(result) != 0 {
+			t.Errorf("ShuffleSeeded() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := ShuffleSeeded([]int(nil), 1); result != nil {
+			t.Errorf("ShuffleSeeded() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSampleWhere(t *testing.T) {
+	origReadRandom := readRandom
+	t.Cleanup(func() { readRandom = origReadRandom })
+	isEven := func(item int, _ int) bool { return item%2 == 0 }
+
+	t.Run("samples n distinct matching elements", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		result := SampleWhere(input, 3, isEven)
+		if len(result) != 3 {
+			t.Fatalf("SampleWhere() length got = %d, want 3", len(result))
+		}
+		seen := make(map[int]bool)
+		for _, v := range result {
+			if v%2 != 0 || seen[v] {
+				t.Errorf("SampleWhere() got = %v, want distinct even values", result)
+			}
+			seen[v] = true
+		}
+	})
+
+	t.Run("returns all matches when fewer than n match", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		result := SampleWhere(input, 10, isEven)
+		if len(result) != 2 || !SetEqual(result, []int{2, 4}) {
+			t.Errorf("SampleWhere() got = %v, want a permutation of [2 4]", result)
+		}
+	})
+
+	t.Run("returns the first n matches on random error", func(t *testing.T) {
+		readRandom = func(b []byte) (int, error) { return 0, assertErr{} }
+		defer func() { readRandom = origReadRandom }()
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		expected := []int{2, 4, 6}
+		result := SampleWhere(input, 3, isEven)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SampleWhere() on error got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when nothing matches or n < 1", func(t *testing.T) {
+		expected := []int{}
+		if result := SampleWhere([]int{1, 3}, 2, isEven); !reflect.DeepEqual(result, expected) {
+			t.Errorf("SampleWhere() got = %v, want %v", result, expected)
+		}
+		if result := SampleWhere([]int{2, 4}, 0, isEven); !reflect.DeepEqual(result, expected) {
+			t.Errorf("SampleWhere() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := SampleWhere(input, 2, isEven)
+		if result != nil {
+			t.Errorf("SampleWhere() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestWeightedSampleN(t *testing.T) {
+	origReadRandom := readRandom
+	t.Cleanup(func() { readRandom = origReadRandom })
+	identity := func(item float64) float64 { return item }
+
+	t.Run("samples n distinct elements with positive weight", func(t *testing.T) {
+		input := []float64{0, 1, 2, 0, 3, -1, 4, 5}
+		for range 50 {
+			result := WeightedSampleN(input, 3, identity)
+			if len(result) != 3 {
+				t.Fatalf("WeightedSampleN() length got = %d, want 3", len(result))
+			}
+			seen := make(map[float64]bool)
+			for _, v := range result {
+				if v <= 0 || seen[v] {
+					t.Fatalf("WeightedSampleN() got = %v, want distinct positively weighted values", result)
+				}
+				seen[v] = true
+			}
+		}
+	})
+
+	t.Run("never samples zero or negative weights", func(t *testing.T) {
+		input := []float64{0, -2, 7, 0, math.NaN()}
+		for range 20 {
+			if result := WeightedSampleN(input, 5, identity); !reflect.DeepEqual(result, []float64{7}) {
+				t.Fatalf("WeightedSampleN() got = %v, want [7]", result)
+			}
+		}
+	})
+
+	t.Run("favors heavier elements", func(t *testing.T) {
+		input := []float64{1, 99}
+		heavyFirst := 0
+		const trials = 2000
+		for range trials {
+			if WeightedSampleN(input, 1, identity)[0] == 99 {
+				heavyFirst++
+			}
+		}
+		// The heavy element is expected 99% of the time; allow a generous margin.
+		if heavyFirst < trials*9/10 {
+			t.Errorf("WeightedSampleN() picked the heavy element %d of %d times, want about 99%%", heavyFirst, trials)
+		}
+	})
+
+	t.Run("returns the first n eligible elements on random error", func(t *testing.T) {
+		readRandom = func(b []byte) (int, error) { return 0, assertErr{} }
+		defer func() { readRandom = origReadRandom }()
+		expected := []float64{2, 3}
+		result := WeightedSampleN([]float64{0, 2, -1, 3, 4}, 2, identity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("WeightedSampleN() on error got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when n < 1 or nothing is eligible", func(t *testing.T) {
+		for _, result := range [][]float64{
+			WeightedSampleN([]float64{1, 2}, 0, identity),
+			WeightedSampleN([]float64{0, -1}, 2, identity),
+			WeightedSampleN([]float64{}, 2, identity),
+		} {
+			if result == nil || len(result) != 0 {
+				t.Errorf("WeightedSampleN() should return empty slice, but got %v", result)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := WeightedSampleN([]float64(nil), 2, identity); result != nil {
+			t.Errorf("WeightedSampleN() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestRandUnitFloat(t *testing.T) {
+	origReadRandom := readRandom
+	t.Cleanup(func() { readRandom = origReadRandom })
+
+	t.Run("maps the extreme inputs into (0, 1]", func(t *testing.T) {
+		for _, fill := range []byte{0x00, 0xff} {
+			readRandom = func(b []byte) (int, error) {
+				for i := range b {
+					b[i] = fill
+				}
+				return len(b), nil
+			}
+			value, err := randUnitFloat()
+			if err != nil || value <= 0 || value > 1 {
+				t.Errorf("randUnitFloat() with bytes %#x got = (%v, %v), want a value in (0, 1]", fill, value, err)
+			}
+		}
+	})
+
+	t.Run("returns the random source error", func(t *testing.T) {
+		readRandom = func(b []byte) (int, error) { return 0, assertErr{} }
+		if _, err := randUnitFloat(); err == nil {
+			t.Errorf("randUnitFloat() should return an error when the source fails")
+		}
+	})
+}
+
+func TestRandIndex(t *testing.T) {
+	origReadRandom := readRandom
+	t.Cleanup(func() { readRandom = origReadRandom })
+
+	t.Run("reads the minimum number of bytes", func(t *testing.T) {
+		cases := []struct {
+			n             int
+			expectedBytes int
+		}{
+			{n: 1, expectedBytes: 0},
+			{n: 2, expectedBytes: 1},
+			{n: 256, expectedBytes: 1},
+			{n: 257, expectedBytes: 2},
+			{n: 65536, expectedBytes: 2},
+			{n: 65537, expectedBytes: 3},
+		}
+		for _, tc := range cases {
+			readBytes := 0
+			readRandom = func(b []byte) (int, error) {
+				readBytes = len(b)
+				clear(b)
+				return len(b), nil
+			}
+			result, err := randIndex(tc.n)
+			if err != nil {
+				t.Fatalf("randIndex(%d) unexpected error: %v", tc.n, err)
+			}
+			if result != 0 {
+				t.Errorf("randIndex(%d) got = %v, want 0", tc.n, result)
+			}
+			if readBytes != tc.expectedBytes {
+				t.Errorf("randIndex(%d) read %d bytes, want %d", tc.n, readBytes, tc.expectedBytes)
+			}
+		}
+	})
+
+	t.Run("returns the largest index without rejection at power-of-two boundaries", func(t *testing.T) {
+		readRandom = func(b []byte) (int, error) {
+			for i := range b {
+				b[i] = 0xff
+			}
+			return len(b), nil
+		}
+		for _, n := range []int{256, 65536} {
+			result, err := randIndex(n)
+			if err != nil {
+				t.Fatalf("randIndex(%d) unexpected error: %v", n, err)
+			}
+			if result != n-1 {
+				t.Errorf("randIndex(%d) got = %v, want %v", n, result, n-1)
+			}
+		}
+	})
+
+	t.Run("rejects values outside the range", func(t *testing.T) {
+		// n=257 masks to 9 bits; 0x01ff (511) is out of range and must be re-read.
+		values := [][]byte{{0xff, 0xff}, {0x01, 0x00}}
+		calls := 0
+		readRandom = func(b []byte) (int, error) {
+			copy(b, values[calls])
+			calls++
+			return len(b), nil
+		}
+		result, err := randIndex(257)
+		if err != nil {
+			t.Fatalf("randIndex() unexpected error: %v", err)
+		}
+		if result != 256 {
+			t.Errorf("randIndex() got = %v, want 256", result)
+		}
+		if calls != 2 {
+			t.Errorf("randIndex() read random %d times, want 2", calls)
+		}
+	})
+
+	t.Run("stays within range", func(t *testing.T) {
+		readRandom = origReadRandom
+		for _, n := range []int{1, 3, 256, 1000, 65536} {
+			for range 100 {
+				result, err := randIndex(n)
+				if err != nil {
+					t.Fatalf("randIndex(%d) unexpected error: %v", n, err)
+				}
+				if result < 0 || result >= n {
+					t.Fatalf("randIndex(%d) got = %v, out of range", n, result)
+				}
+			}
+		}
+	})
+
+	t.Run("returns error for n < 1", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			if _, err := randIndex(n); err == nil {
+				t.Errorf("randIndex(%d) should return an error", n)
+			}
+		}
+	})
+
+	t.Run("returns error when random source fails", func(t *testing.T) {
+		readRandom = func(b []byte) (int, error) { return 0, assertErr{} }
+		if _, err := randIndex(10); err == nil {
+			t.Errorf("randIndex() should propagate random source errors")
+		}
+	})
 }
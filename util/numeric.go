@@ -0,0 +1,365 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Integer is a constraint that permits any signed or unsigned integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// Clamp returns a new slice in which each element is bounded to the range [lower, upper].
+// Elements below lower become lower and elements above upper become upper.
+// If lower is greater than upper, the bounds are swapped before clamping.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func Clamp[E cmp.Ordered](collection []E, lower, upper E) []E {
+	if collection == nil {
+		return nil
+	}
+
+	if lower > upper {
+		lower, upper = upper, lower
+	}
+
+	result := make([]E, len(collection))
+	for i, item := range collection {
+		result[i] = min(max(item, lower), upper)
+	}
+	return result
+}
+
+// Normalize returns a new slice with each element scaled linearly into the unit interval,
+// so that the smallest element maps to 0 and the largest maps to 1.
+// When all elements are equal (max == min) every result is 0, avoiding a division by zero.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func Normalize[E Number](collection []E) []float64 {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]float64, len(collection))
+	if len(collection) == 0 {
+		return result
+	}
+
+	lowest, highest := collection[0], collection[0]
+	for _, item := range collection[1:] {
+		lowest = min(lowest, item)
+		highest = max(highest, item)
+	}
+
+	if lowest == highest {
+		return result
+	}
+
+	span := float64(highest) - float64(lowest)
+	for i, item := range collection {
+		result[i] = (float64(item) - float64(lowest)) / span
+	}
+	return result
+}
+
+// Differences returns the element-wise differences between consecutive elements, that is
+// collection[i+1] - collection[i] for each i, so the result has one element fewer than the
+// input. It is the discrete derivative of the slice and the inverse of a running sum.
+// It returns nil for nil input and an empty (non-nil) slice when there are fewer than two
+// elements.
+func Differences[E Number](collection []E) []E {
+	if collection == nil {
+		return nil
+	}
+	if len(collection) < 2 {
+		return []E{}
+	}
+
+	result := make([]E, len(collection)-1)
+	for i := range result {
+		result[i] = collection[i+1] - collection[i]
+	}
+	return result
+}
+
+// subsetSumDenseLimit is the largest target for which HasSubsetSum uses a dense table of
+// target+1 entries; larger targets track the reachable sums sparsely instead.
+const subsetSumDenseLimit = 1 << 20
+
+// HasSubsetSum reports whether any subset of the collection sums exactly to target.
+// The empty subset sums to 0, so a zero target is always reachable and an empty
+// collection only reaches a zero target.
+//
+// It is intended for non-negative integers: negative elements are skipped and a negative
+// target always returns false. The usable elements are summed first, which returns true as
+// soon as a prefix hits target exactly and false if they cannot reach it at all. For
+// targets up to 1<<20 a dense dynamic programming table is used, so time is O(n*target)
+// and memory is O(target). Larger targets keep only the distinct reachable sums, which is
+// fast for few elements but can grow to min(2^n, target) sums in the worst case.
+func HasSubsetSum[E Integer](collection []E, target E) bool {
+	if target < 0 {
+		return false
+	}
+
+	goal := uint64(target)
+	items := make([]uint64, 0, len(collection))
+	var total uint64
+	reached := false
+	for _, item := range collection {
+		if item < 0 || item > target {
+			continue
+		}
+		value := uint64(item)
+		items = append(items, value)
+		if reached {
+			continue
+		}
+
+		// Comparing against the remaining gap avoids overflow, since value <= goal.
+		switch gap := goal - total; {
+		case value == gap:
+			return true
+		case value > gap:
+			reached = true
+		default:
+			total += value
+		}
+	}
+	if !reached {
+		return total == goal
+	}
+
+	if goal <= subsetSumDenseLimit {
+		return subsetSumDense(items, goal)
+	}
+	return subsetSumSparse(items, goal)
+}
+
+// subsetSumDense implements HasSubsetSum with a table of goal+1 reachable flags.
+func subsetSumDense(items []uint64, goal uint64) bool {
+	reachable := make([]bool, goal+1)
+	reachable[0] = true
+	for _, value := range items {
+		// Iterate downwards so each element is used at most once
+		for sum := goal; sum >= value && sum > 0; sum-- {
+			if reachable[sum-value] {
+				reachable[sum] = true
+			}
+		}
+		if reachable[goal] {
+			return true
+		}
+	}
+	return false
+}
+
+// subsetSumSparse implements HasSubsetSum for large goals by tracking only the distinct
+// sums that are reachable and do not exceed goal.
+func subsetSumSparse(items []uint64, goal uint64) bool {
+	reachable := map[uint64]struct{}{0: {}}
+	added := []uint64{}
+	for _, value := range items {
+		added = added[:0]
+		for sum := range reachable {
+			if value > goal-sum {
+				continue
+			}
+			if sum+value == goal {
+				return true
+			}
+			added = append(added, sum+value)
+		}
+		for _, sum := range added {
+			reachable[sum] = struct{}{}
+		}
+	}
+	return false
+}
+
+// Histogram divides the [min, max] range of the collection into bins equal-width buckets
+// and counts the elements that fall into each one. It also returns the lower bound of
+// every bucket.
+//
+// Each bucket is half-open, [lower, lower+width), except the last one, which also includes
+// max so that the largest element is always counted. When all elements are equal the width
+// is zero and every element is counted in the first bucket.
+//
+// Bucket indices are clamped into [0, bins-1], so an infinite min or max does not cause a
+// panic. The width is then infinite: elements equal to min are counted in the first bucket,
+// and the others in the first bucket if only max is infinite or in the last if min is -Inf.
+// It returns (nil, nil) for nil input or if bins is less than 1, and empty (non-nil) slices
+// for empty input since there is no range to divide.
+func Histogram[E Number](collection []E, bins int) (counts []int, binMin []float64) {
+	if collection == nil || bins < 1 {
+		return nil, nil
+	}
+	if len(collection) == 0 {
+		return []int{}, []float64{}
+	}
+
+	lowest, highest := collection[0], collection[0]
+	for _, item := range collection[1:] {
+		lowest = min(lowest, item)
+		highest = max(highest, item)
+	}
+
+	width := (float64(highest) - float64(lowest)) / float64(bins)
+	counts = make([]int, bins)
+	binMin = make([]float64, bins)
+	for i := range binMin {
+		binMin[i] = float64(lowest) + float64(i)*width
+	}
+
+	for _, item := range collection {
+		bucket := 0
+		if width > 0 {
+			position := (float64(item) - float64(lowest)) / width
+			switch {
+			case math.IsNaN(position):
+				// Only infinite bounds get here, as Inf-Inf or Inf/Inf; keep elements equal
+				// to min in the first bucket and send the rest to the last.
+				if item != lowest {
+					bucket = bins - 1
+				}
+			default:
+				bucket = int(max(min(position, float64(bins-1)), 0))
+			}
+		}
+		counts[bucket]++
+	}
+	return counts, binMin
+}
+
+// GroupByRange groups numeric values into buckets delimited by boundaries, which must be
+// sorted in ascending order. Bucket i holds the values in [boundaries[i-1], boundaries[i]),
+// so each boundary is inclusive as the lower edge of the bucket above it. Values below
+// boundaries[0] fall into bucket 0 and values greater than or equal to the last boundary
+// fall into bucket len(boundaries). Only non-empty buckets appear in the result, and each
+// bucket keeps the original order of its values.
+// It returns nil for nil input and an empty (non-nil) map for empty input.
+func GroupByRange[E Number](collection []E, boundaries []E) map[int][]E {
+	if collection == nil {
+		return nil
+	}
+
+	result := make(map[int][]E)
+	for _, item := range collection {
+		bucket := sort.Search(len(boundaries), func(i int) bool { return boundaries[i] > item })
+		result[bucket] = append(result[bucket], item)
+	}
+	return result
+}
+
+// PartitionBalanced splits a numeric slice into two groups whose sums are nearly equal.
+// It uses the greedy largest-first heuristic: elements are considered in descending order
+// and each is assigned to the group with the currently smaller sum (group A on ties).
+// This is fast but not guaranteed to find the optimal split. Every element is assigned to
+// exactly one group, and each group keeps the original relative order of its elements.
+// It returns (nil, nil) for nil input.
+func PartitionBalanced[E Number](collection []E) (groupA []E, groupB []E) {
+	if collection == nil {
+		return nil, nil
+	}
+
+	order := make([]int, len(collection))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(collection[b], collection[a])
+	})
+
+	inA := make([]bool, len(collection))
+	var sumA, sumB E
+	for _, i := range order {
+		if sumA <= sumB {
+			inA[i] = true
+			sumA += collection[i]
+		} else {
+			sumB += collection[i]
+		}
+	}
+
+	groupA, groupB = []E{}, []E{}
+	for i, item := range collection {
+		if inA[i] {
+			groupA = append(groupA, item)
+		} else {
+			groupB = append(groupB, item)
+		}
+	}
+	return groupA, groupB
+}
+
+// EqualApprox reports whether a and b have the same length and every pair of elements at
+// the same index differs by at most epsilon. Elements that are exactly equal always match,
+// so equal infinities compare equal. NaN is treated as equal to NaN, so slices containing
+// NaN at the same positions can still be equal, while NaN never matches a number.
+// Length alone decides for empty input, so nil and empty slices are equal to each other.
+func EqualApprox[E Float](a, b []E, epsilon E) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		x, y := a[i], b[i]
+		switch {
+		case x == y:
+		case math.IsNaN(float64(x)) || math.IsNaN(float64(y)):
+			if !math.IsNaN(float64(x)) || !math.IsNaN(float64(y)) {
+				return false
+			}
+		case x-y > epsilon || y-x > epsilon:
+			return false
+		}
+	}
+	return true
+}
+
+// FindNearest returns the element closest to target, its index, and a boolean indicating
+// whether the slice was non-empty, which makes it handy for snapping values to a grid.
+// On ties, when two elements are equally distant from target, the earlier one is returned.
+// For nil or empty input it returns the zero value, -1, and false.
+func FindNearest[E Number](collection []E, target E) (E, int, bool) {
+	var zero E
+	if len(collection) == 0 {
+		return zero, -1, false
+	}
+
+	// Subtracting in E would overflow for signed integers near the ends of their range, so
+	// integer distances are computed as a modular uint64 difference, which is exact because
+	// the true distance always fits, and float distances are computed in float64.
+	isFloat := E(1)/2 != 0
+	distance := func(item E) (uint64, float64) {
+		switch {
+		case isFloat:
+			return 0, math.Abs(float64(item) - float64(target))
+		case item >= target:
+			return uint64(item) - uint64(target), 0
+		default:
+			return uint64(target) - uint64(item), 0
+		}
+	}
+
+	bestIndex := 0
+	bestInt, bestFloat := distance(collection[0])
+	for i, item := range collection[1:] {
+		if dInt, dFloat := distance(item); dInt < bestInt || dFloat < bestFloat {
+			bestIndex, bestInt, bestFloat = i+1, dInt, dFloat
+		}
+	}
+	return collection[bestIndex], bestIndex, true
+}
@@ -0,0 +1,480 @@
+package util
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	t.Run("bounds values below, above and within range", func(t *testing.T) {
+		input := []int{-5, 0, 5, 10, 15}
+		expected := []int{0, 0, 5, 10, 10}
+		result := Clamp(input, 0, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Clamp() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("works with floats", func(t *testing.T) {
+		input := []float64{-0.5, 0.25, 1.5}
+		expected := []float64{0, 0.25, 1}
+		result := Clamp(input, 0.0, 1.0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Clamp() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("swaps bounds when lower > upper", func(t *testing.T) {
+		input := []int{-5, 5, 15}
+		expected := []int{0, 5, 10}
+		result := Clamp(input, 10, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Clamp() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("does not modify the input", func(t *testing.T) {
+		input := []int{-1, 11}
+		Clamp(input, 0, 10)
+		if !reflect.DeepEqual(input, []int{-1, 11}) {
+			t.Errorf("Clamp() modified input: got %v", input)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []int{}
+		expected := []int{}
+		result := Clamp(input, 0, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Clamp() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Clamp(input, 0, 10)
+		if result != nil {
+			t.Errorf("Clamp() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("scales a typical range into [0,1]", func(t *testing.T) {
+		input := []int{10, 20, 15, 30}
+		expected := []float64{0, 0.5, 0.25, 1}
+		result := Normalize(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Normalize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("handles negative floats", func(t *testing.T) {
+		input := []float64{-2, 0, 2}
+		expected := []float64{0, 0.5, 1}
+		result := Normalize(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Normalize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns all zeros when all elements are equal", func(t *testing.T) {
+		input := []int{7, 7, 7}
+		expected := []float64{0, 0, 0}
+		result := Normalize(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Normalize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []int{}
+		expected := []float64{}
+		result := Normalize(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Normalize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Normalize(input)
+		if result != nil {
+			t.Errorf("Normalize() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestDifferences(t *testing.T) {
+	t.Run("returns differences between consecutive elements", func(t *testing.T) {
+		input := []int{1, 3, 6, 10}
+		expected := []int{2, 3, 4}
+		result := Differences(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Differences() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("handles decreasing floats", func(t *testing.T) {
+		input := []float64{5, 4.5, 4.5}
+		expected := []float64{-0.5, 0}
+		result := Differences(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Differences() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for fewer than two elements", func(t *testing.T) {
+		expected := []int{}
+		if result := Differences([]int{7}); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Differences() got = %v, want %v", result, expected)
+		}
+		if result := Differences([]int{}); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Differences() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Differences(input)
+		if result != nil {
+			t.Errorf("Differences() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestHasSubsetSum(t *testing.T) {
+	t.Run("finds a reachable target", func(t *testing.T) {
+		input := []int{3, 34, 4, 12, 5, 2}
+		if !HasSubsetSum(input, 9) {
+			t.Errorf("HasSubsetSum() should find a subset summing to 9 in %v", input)
+		}
+	})
+
+	t.Run("uses each element at most once", func(t *testing.T) {
+		input := []uint{5, 7}
+		if HasSubsetSum(input, 10) {
+			t.Errorf("HasSubsetSum() should not reuse 5 to reach 10 in %v", input)
+		}
+	})
+
+	t.Run("returns false for an unreachable target", func(t *testing.T) {
+		input := []int{3, 34, 4, 12, 5, 2}
+		if HasSubsetSum(input, 30) {
+			t.Errorf("HasSubsetSum() should not find a subset summing to 30 in %v", input)
+		}
+	})
+
+	t.Run("empty input reaches only a zero target", func(t *testing.T) {
+		input := []int{}
+		if !HasSubsetSum(input, 0) {
+			t.Errorf("HasSubsetSum() on empty slice should reach target 0")
+		}
+		if HasSubsetSum(input, 1) {
+			t.Errorf("HasSubsetSum() on empty slice should not reach target 1")
+		}
+	})
+
+	t.Run("returns false for a negative target", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		if HasSubsetSum(input, -1) {
+			t.Errorf("HasSubsetSum() should return false for a negative target")
+		}
+	})
+
+	t.Run("returns false for a target far above the input sum", func(t *testing.T) {
+		if HasSubsetSum([]int{1, 2}, math.MaxInt) {
+			t.Errorf("HasSubsetSum() should not reach math.MaxInt with input [1 2]")
+		}
+		if HasSubsetSum([]uint{1, 2}, math.MaxUint) {
+			t.Errorf("HasSubsetSum() should not reach math.MaxUint with input [1 2]")
+		}
+		if HasSubsetSum([]uint64{math.MaxUint64 / 2, 3}, math.MaxUint64) {
+			t.Errorf("HasSubsetSum() should not reach math.MaxUint64 with input [MaxUint64/2 3]")
+		}
+	})
+
+	t.Run("reaches targets at the limits of the type without panicking", func(t *testing.T) {
+		if !HasSubsetSum([]uint64{math.MaxUint64}, math.MaxUint64) {
+			t.Errorf("HasSubsetSum() should reach math.MaxUint64 with input [MaxUint64]")
+		}
+		if !HasSubsetSum([]int{math.MaxInt}, math.MaxInt) {
+			t.Errorf("HasSubsetSum() should reach math.MaxInt with input [MaxInt]")
+		}
+		if !HasSubsetSum([]uint64{5, math.MaxUint64 - 1, 1}, math.MaxUint64) {
+			t.Errorf("HasSubsetSum() should reach math.MaxUint64 with input [5 MaxUint64-1 1]")
+		}
+	})
+
+	t.Run("handles large targets without a dense table", func(t *testing.T) {
+		if !HasSubsetSum([]int64{3_000_000_000}, 3_000_000_000) {
+			t.Errorf("HasSubsetSum() should reach 3e9 with input [3e9]")
+		}
+		input := []int64{2_000_000_000, 7, 1_000_000_000, 5, 9}
+		if !HasSubsetSum(input, 3_000_000_005) {
+			t.Errorf("HasSubsetSum() should reach 3_000_000_005 with input %v", input)
+		}
+		if HasSubsetSum(input, 3_000_000_001) {
+			t.Errorf("HasSubsetSum() should not reach 3_000_000_001 with input %v", input)
+		}
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	t.Run("counts a uniform spread across 4 bins", func(t *testing.T) {
+		input := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}
+		expectedCounts := []int{2, 2, 2, 3}
+		expectedMin := []float64{0, 2, 4, 6}
+		counts, binMin := Histogram(input, 4)
+		if !reflect.DeepEqual(counts, expectedCounts) {
+			t.Errorf("Histogram() counts got = %v, want %v", counts, expectedCounts)
+		}
+		if !reflect.DeepEqual(binMin, expectedMin) {
+			t.Errorf("Histogram() binMin got = %v, want %v", binMin, expectedMin)
+		}
+	})
+
+	t.Run("places max in the last bucket", func(t *testing.T) {
+		input := []float64{0, 10}
+		expectedCounts := []int{1, 0, 1}
+		counts, _ := Histogram(input, 3)
+		if !reflect.DeepEqual(counts, expectedCounts) {
+			t.Errorf("Histogram() counts got = %v, want %v", counts, expectedCounts)
+		}
+	})
+
+	t.Run("counts equal elements in the first bucket", func(t *testing.T) {
+		input := []int{5, 5, 5}
+		expectedCounts := []int{3, 0}
+		expectedMin := []float64{5, 5}
+		counts, binMin := Histogram(input, 2)
+		if !reflect.DeepEqual(counts, expectedCounts) {
+			t.Errorf("Histogram() counts got = %v, want %v", counts, expectedCounts)
+		}
+		if !reflect.DeepEqual(binMin, expectedMin) {
+			t.Errorf("Histogram() binMin got = %v, want %v", binMin, expectedMin)
+		}
+	})
+
+	t.Run("returns empty slices for empty input", func(t *testing.T) {
+		counts, binMin := Histogram([]int{}, 3)
+		if counts == nil || len(counts) != 0 || binMin == nil || len(binMin) != 0 {
+			t.Errorf("Histogram() on empty slice should return empty non-nil slices, got (%v, %v)", counts, binMin)
+		}
+	})
+
+	t.Run("clamps buckets for infinite bounds instead of panicking", func(t *testing.T) {
+		counts, _ := Histogram([]float64{0, math.Inf(1)}, 4)
+		if expected := []int{1, 0, 0, 1}; !reflect.DeepEqual(counts, expected) {
+			t.Errorf("Histogram() with +Inf max got = %v, want %v", counts, expected)
+		}
+		counts, _ = Histogram([]float64{math.Inf(-1), 3, math.Inf(1)}, 2)
+		if expected := []int{1, 2}; !reflect.DeepEqual(counts, expected) {
+			t.Errorf("Histogram() with infinite min and max got = %v, want %v", counts, expected)
+		}
+	})
+
+	t.Run("returns nil for invalid bins or nil input", func(t *testing.T) {
+		if counts, binMin := Histogram([]int{1, 2}, 0); counts != nil || binMin != nil {
+			t.Errorf("Histogram() with bins 0 should return (nil, nil), got (%v, %v)", counts, binMin)
+		}
+		var input []int
+		if counts, binMin := Histogram(input, 2); counts != nil || binMin != nil {
+			t.Errorf("Histogram() on nil slice should return (nil, nil), got (%v, %v)", counts, binMin)
+		}
+	})
+}
+
+func TestGroupByRange(t *testing.T) {
+	t.Run("assigns values spanning all buckets", func(t *testing.T) {
+		input := []int{5, 10, 15, 20, 25, 29, 30, 45, -3}
+		expected := map[int][]int{
+			0: {5, -3},
+			1: {10, 15},
+			2: {20, 25, 29},
+			3: {30, 45},
+		}
+		result := GroupByRange(input, []int{10, 20, 30})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupByRange() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("puts everything in bucket 0 without boundaries", func(t *testing.T) {
+		expected := map[int][]float64{0: {1.5, -2}}
+		result := GroupByRange([]float64{1.5, -2}, nil)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupByRange() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty slice", func(t *testing.T) {
+		result := GroupByRange([]int{}, []int{10})
+		if result == nil || len(result) != 0 {
+			t.Errorf("GroupByRange() on empty slice should return empty map, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := GroupByRange(nil, []int{10}); result != nil {
+			t.Errorf("GroupByRange() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestPartitionBalanced(t *testing.T) {
+	sum := func(values []int) int {
+		total := 0
+		for _, v := range values {
+			total += v
+		}
+		return total
+	}
+
+	t.Run("splits into groups with a small sum difference", func(t *testing.T) {
+		input := []int{8, 7, 6, 5, 4}
+		groupA, groupB := PartitionBalanced(input)
+		diff := sum(groupA) - sum(groupB)
+		if diff < -4 || diff > 4 {
+			t.Errorf("PartitionBalanced() sum difference got = %d, want at most 4 (A=%v, B=%v)", diff, groupA, groupB)
+		}
+	})
+
+	t.Run("assigns every element exactly once", func(t *testing.T) {
+		input := []int{3, 1, 4, 1, 5, 9, 2, 6}
+		groupA, groupB := PartitionBalanced(input)
+		counts := make(map[int]int)
+		for _, v := range input {
+			counts[v]++
+		}
+		for _, v := range slices.Concat(groupA, groupB) {
+			counts[v]--
+		}
+		for v, count := range counts {
+			if count != 0 {
+				t.Errorf("PartitionBalanced() element %v assigned incorrectly (off by %d)", v, count)
+			}
+		}
+	})
+
+	t.Run("preserves original relative order within groups", func(t *testing.T) {
+		input := []int{1, 5, 2, 4}
+		expectedA := []int{1, 5}
+		expectedB := []int{2, 4}
+		groupA, groupB := PartitionBalanced(input)
+		if !reflect.DeepEqual(groupA, expectedA) || !reflect.DeepEqual(groupB, expectedB) {
+			t.Errorf("PartitionBalanced() got = (%v, %v), want (%v, %v)", groupA, groupB, expectedA, expectedB)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		groupA, groupB := PartitionBalanced(input)
+		if groupA != nil || groupB != nil {
+			t.Errorf("PartitionBalanced() on nil slice should return (nil, nil), got (%v, %v)", groupA, groupB)
+		}
+	})
+}
+
+func TestEqualApprox(t *testing.T) {
+	t.Run("returns true for values within epsilon", func(t *testing.T) {
+		a := []float64{1.0, 2.0, 3.0}
+		b := []float64{1.0005, 1.9995, 3.0}
+		if !EqualApprox(a, b, 0.001) {
+			t.Errorf("EqualApprox(%v, %v, 0.001) got = false, want true", a, b)
+		}
+	})
+
+	t.Run("returns false for values outside epsilon", func(t *testing.T) {
+		a := []float32{1.0, 2.0}
+		b := []float32{1.0, 2.1}
+		if EqualApprox(a, b, 0.01) {
+			t.Errorf("EqualApprox(%v, %v, 0.01) got = true, want false", a, b)
+		}
+	})
+
+	t.Run("treats NaN as equal to NaN only", func(t *testing.T) {
+		nan := math.NaN()
+		if !EqualApprox([]float64{1, nan}, []float64{1, nan}, 0.1) {
+			t.Errorf("EqualApprox() with NaN at the same position got = false, want true")
+		}
+		if EqualApprox([]float64{nan}, []float64{1}, math.Inf(1)) {
+			t.Errorf("EqualApprox() with NaN against a number got = true, want false")
+		}
+	})
+
+	t.Run("compares equal infinities as equal", func(t *testing.T) {
+		inf := math.Inf(1)
+		if !EqualApprox([]float64{inf, -inf}, []float64{inf, -inf}, 0) {
+			t.Errorf("EqualApprox() with equal infinities got = false, want true")
+		}
+	})
+
+	t.Run("returns false for different lengths", func(t *testing.T) {
+		if EqualApprox([]float64{1}, []float64{1, 2}, 1) {
+			t.Errorf("EqualApprox() with different lengths got = true, want false")
+		}
+	})
+
+	t.Run("returns true for two nil slices", func(t *testing.T) {
+		if !EqualApprox[float64](nil, nil, 0) {
+			t.Errorf("EqualApprox() on two nil slices got = false, want true")
+		}
+	})
+}
+
+func TestFindNearest(t *testing.T) {
+	t.Run("returns the closer of two surrounding elements", func(t *testing.T) {
+		item, index, found := FindNearest([]int{0, 10, 20, 30}, 17)
+		if item != 20 || index != 2 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (20, 2, true)", item, index, found)
+		}
+	})
+
+	t.Run("returns an exact match", func(t *testing.T) {
+		item, index, found := FindNearest([]float64{0.5, 1.5, 2.5}, 1.5)
+		if item != 1.5 || index != 1 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (1.5, 1, true)", item, index, found)
+		}
+	})
+
+	t.Run("returns the earlier element on ties", func(t *testing.T) {
+		item, index, found := FindNearest([]int{30, 10, 20}, 15)
+		if item != 10 || index != 1 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (10, 1, true)", item, index, found)
+		}
+	})
+
+	t.Run("works with unsigned values below the target", func(t *testing.T) {
+		item, index, found := FindNearest([]uint{1, 8, 3}, 5)
+		if item != 3 || index != 2 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (3, 2, true)", item, index, found)
+		}
+	})
+
+	t.Run("does not overflow near the limits of signed types", func(t *testing.T) {
+		item, index, found := FindNearest([]int8{-100, 100}, 100)
+		if item != 100 || index != 1 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (100, 1, true)", item, index, found)
+		}
+		item, index, found = FindNearest([]int8{127, -100}, -100)
+		if item != -100 || index != 1 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (-100, 1, true)", item, index, found)
+		}
+		item, index, found = FindNearest([]int8{math.MinInt8, math.MaxInt8}, 1)
+		if item != math.MaxInt8 || index != 1 || !found {
+			t.Errorf("FindNearest() got = (%v, %v, %v), want (%v, 1, true)", item, index, found, math.MaxInt8)
+		}
+	})
+
+	t.Run("returns not found for empty and nil slices", func(t *testing.T) {
+		for _, input := range [][]int{nil, {}} {
+			item, index, found := FindNearest(input, 1)
+			if item != 0 || index != -1 || found {
+				t.Errorf("FindNearest(%v) got = (%v, %v, %v), want (0, -1, false)", input, item, index, found)
+			}
+		}
+	})
+}
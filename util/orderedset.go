@@ -0,0 +1,71 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "slices"
+
+// OrderedSet is a set of comparable values that remembers the order in which values were
+// first added. Membership checks are O(1); removal is O(n) because later elements shift
+// to keep the insertion order compact. The zero value is ready to use.
+//
+// OrderedSet is not safe for concurrent use; callers must synchronize access.
+type OrderedSet[E comparable] struct {
+	index  map[E]int
+	values []E
+}
+
+// NewOrderedSet returns an OrderedSet containing the given values, in order, with
+// duplicates ignored.
+func NewOrderedSet[E comparable](values ...E) *OrderedSet[E] {
+	set := &OrderedSet[E]{index: make(map[E]int, len(values))}
+	for _, value := range values {
+		set.Add(value)
+	}
+	return set
+}
+
+// Add inserts value at the end of the set and reports whether it was newly added.
+// Adding a value that is already present leaves its position unchanged.
+func (s *OrderedSet[E]) Add(value E) bool {
+	if _, exists := s.index[value]; exists {
+		return false
+	}
+
+	if s.index == nil {
+		s.index = make(map[E]int)
+	}
+	s.index[value] = len(s.values)
+	s.values = append(s.values, value)
+	return true
+}
+
+// Contains reports whether value is in the set.
+func (s *OrderedSet[E]) Contains(value E) bool {
+	_, exists := s.index[value]
+	return exists
+}
+
+// Remove deletes value from the set and reports whether it was present.
+func (s *OrderedSet[E]) Remove(value E) bool {
+	position, exists := s.index[value]
+	if !exists {
+		return false
+	}
+
+	delete(s.index, value)
+	s.values = slices.Delete(s.values, position, position+1)
+	for i := position; i < len(s.values); i++ {
+		s.index[s.values[i]] = i
+	}
+	return true
+}
+
+// Len returns the number of values in the set.
+func (s *OrderedSet[E]) Len() int {
+	return len(s.values)
+}
+
+// Values returns the values of the set in insertion order. The returned slice is a copy
+// and may be modified freely without affecting the set.
+func (s *OrderedSet[E]) Values() []E {
+	return slices.Clone(s.values)
+}
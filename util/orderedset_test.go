@@ -0,0 +1,65 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedSet(t *testing.T) {
+	t.Run("preserves insertion order after adds and removes", func(t *testing.T) {
+		set := NewOrderedSet[string]()
+		for _, v := range []string{"c", "a", "b", "a", "d"} {
+			set.Add(v)
+		}
+		set.Remove("a")
+		set.Add("a")
+		set.Remove("c")
+
+		expected := []string{"b", "d", "a"}
+		if got := set.Values(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("OrderedSet.Values() got = %v, want %v", got, expected)
+		}
+		if set.Len() != 3 {
+			t.Errorf("OrderedSet.Len() got = %v, want 3", set.Len())
+		}
+	})
+
+	t.Run("reports whether values were added or removed", func(t *testing.T) {
+		set := NewOrderedSet(1, 2)
+		if set.Add(1) {
+			t.Errorf("OrderedSet.Add() of existing value should return false")
+		}
+		if !set.Add(3) {
+			t.Errorf("OrderedSet.Add() of new value should return true")
+		}
+		if !set.Remove(2) {
+			t.Errorf("OrderedSet.Remove() of existing value should return true")
+		}
+		if set.Remove(2) {
+			t.Errorf("OrderedSet.Remove() of missing value should return false")
+		}
+		if !set.Contains(3) || set.Contains(2) {
+			t.Errorf("OrderedSet.Contains() got wrong membership for %v", set.Values())
+		}
+	})
+
+	t.Run("returns a copy from Values", func(t *testing.T) {
+		set := NewOrderedSet(1, 2, 3)
+		values := set.Values()
+		values[0] = 99
+		if set.Contains(99) || !reflect.DeepEqual(set.Values(), []int{1, 2, 3}) {
+			t.Errorf("OrderedSet.Values() should return a copy, not the internal slice")
+		}
+	})
+
+	t.Run("zero value is ready to use", func(t *testing.T) {
+		var set OrderedSet[int]
+		if set.Contains(1) || set.Remove(1) || set.Len() != 0 {
+			t.Errorf("zero OrderedSet should be empty")
+		}
+		set.Add(1)
+		if !set.Contains(1) {
+			t.Errorf("OrderedSet.Contains() should find added value")
+		}
+	})
+}
@@ -0,0 +1,35 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "sync"
+
+// SlicePool is a pool of reusable slices built on sync.Pool. It lets high-throughput
+// callers recycle backing arrays across calls such as MapInto instead of allocating a new
+// slice each time. It is safe for concurrent use, and the zero value is ready to use.
+type SlicePool[E any] struct {
+	pool sync.Pool
+}
+
+// Get returns an empty slice with a capacity of at least minCap. A pooled backing array is
+// reused when one with enough capacity is available; otherwise a new one is allocated.
+// A negative minCap is treated as zero.
+func (p *SlicePool[E]) Get(minCap int) []E {
+	minCap = max(minCap, 0)
+	if pooled, ok := p.pool.Get().(*[]E); ok && cap(*pooled) >= minCap {
+		return (*pooled)[:0]
+	}
+	return make([]E, 0, minCap)
+}
+
+// Put returns s to the pool for reuse. Its elements are zeroed first so the pool does not
+// keep them reachable. The caller must not use s after calling Put. Slices with no
+// capacity are discarded.
+func (p *SlicePool[E]) Put(s []E) {
+	if cap(s) == 0 {
+		return
+	}
+	s = s[:cap(s)]
+	clear(s)
+	s = s[:0]
+	p.pool.Put(&s)
+}
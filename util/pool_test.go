@@ -0,0 +1,73 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlicePool(t *testing.T) {
+	t.Run("Get honors the minimum capacity", func(t *testing.T) {
+		var pool SlicePool[int]
+		for _, minCap := range []int{0, 1, 16, 1000} {
+			s := pool.Get(minCap)
+			if len(s) != 0 {
+				t.Errorf("Get(%d) len got = %d, want 0", minCap, len(s))
+			}
+			if cap(s) < minCap {
+				t.Errorf("Get(%d) cap got = %d, want at least %d", minCap, cap(s), minCap)
+			}
+			pool.Put(s)
+		}
+	})
+
+	t.Run("Get after Put can return a previously used backing array", func(t *testing.T) {
+		var pool SlicePool[int]
+		// sync.Pool may drop items at any time, so allow a few attempts.
+		reused := false
+		for attempt := 0; attempt < 100 && !reused; attempt++ {
+			s := pool.Get(8)
+			s = append(s, 1, 2, 3)
+			first := &s[:1][0]
+			pool.Put(s)
+
+			again := pool.Get(8)
+			again = again[:1]
+			reused = &again[0] == first
+			pool.Put(again[:0])
+		}
+		if !reused {
+			t.Errorf("Get() never returned a backing array that was Put")
+		}
+	})
+
+	t.Run("Put zeroes elements before reuse", func(t *testing.T) {
+		var pool SlicePool[*int]
+		value := 1
+		s := append(pool.Get(4), &value, &value)
+		pool.Put(s)
+		for _, item := range s[:cap(s)] {
+			if item != nil {
+				t.Fatalf("Put() should zero elements, but found %v", item)
+			}
+		}
+	})
+
+	t.Run("Get allocates when the pooled slice is too small", func(t *testing.T) {
+		var pool SlicePool[int]
+		pool.Put(make([]int, 0, 2))
+		if s := pool.Get(64); cap(s) < 64 {
+			t.Errorf("Get(64) cap got = %d, want at least 64", cap(s))
+		}
+	})
+
+	t.Run("feeds MapInto", func(t *testing.T) {
+		var pool SlicePool[int]
+		dst := pool.Get(3)
+		got := MapInto(dst, []int{1, 2, 3}, func(item, _ int) int { return item * 2 })
+		want := []int{2, 4, 6}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("MapInto() with pooled slice got = %v, want %v", got, want)
+		}
+		pool.Put(got)
+	})
+}
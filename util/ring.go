@@ -0,0 +1,54 @@
+// Package util provides utility functions for working with slices.
+package util
+
+// Ring is a fixed-capacity ring buffer backed by a slice. Once it is full, each Push
+// overwrites the oldest value, which makes it suitable for keeping a bounded history of a
+// stream. Create one with NewRing; the zero value has no capacity and discards every push.
+//
+// Ring is not safe for concurrent use; callers must synchronize access.
+type Ring[E any] struct {
+	buffer []E
+	start  int
+	length int
+}
+
+// NewRing returns an empty Ring that holds up to capacity values. A capacity less than 1
+// is treated as 1.
+func NewRing[E any](capacity int) *Ring[E] {
+	return &Ring[E]{buffer: make([]E, max(capacity, 1))}
+}
+
+// Push appends value as the newest element, overwriting the oldest one if the ring is full.
+func (r *Ring[E]) Push(value E) {
+	if len(r.buffer) == 0 {
+		return
+	}
+
+	if r.length < len(r.buffer) {
+		r.buffer[(r.start+r.length)%len(r.buffer)] = value
+		r.length++
+		return
+	}
+	r.buffer[r.start] = value
+	r.start = (r.start + 1) % len(r.buffer)
+}
+
+// Len returns the number of values currently held, which never exceeds Cap.
+func (r *Ring[E]) Len() int {
+	return r.length
+}
+
+// Cap returns the maximum number of values the ring can hold.
+func (r *Ring[E]) Cap() int {
+	return len(r.buffer)
+}
+
+// Values returns the current contents ordered from oldest to newest. The returned slice is
+// a copy and may be modified freely without affecting the ring.
+func (r *Ring[E]) Values() []E {
+	result := make([]E, r.length)
+	for i := range result {
+		result[i] = r.buffer[(r.start+i)%len(r.buffer)]
+	}
+	return result
+}
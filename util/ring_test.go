@@ -0,0 +1,71 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	t.Run("returns values oldest to newest before it is full", func(t *testing.T) {
+		ring := NewRing[int](4)
+		ring.Push(1)
+		ring.Push(2)
+
+		if got := ring.Values(); !reflect.DeepEqual(got, []int{1, 2}) {
+			t.Errorf("Ring.Values() got = %v, want %v", got, []int{1, 2})
+		}
+		if ring.Len() != 2 || ring.Cap() != 4 {
+			t.Errorf("Ring.Len(), Ring.Cap() got = %v, %v, want 2, 4", ring.Len(), ring.Cap())
+		}
+	})
+
+	t.Run("overwrites the oldest values after wraparound", func(t *testing.T) {
+		ring := NewRing[string](3)
+		for _, v := range []string{"a", "b", "c", "d", "e"} {
+			ring.Push(v)
+		}
+
+		expected := []string{"c", "d", "e"}
+		if got := ring.Values(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Ring.Values() got = %v, want %v", got, expected)
+		}
+		if ring.Len() != 3 {
+			t.Errorf("Ring.Len() got = %v, want 3", ring.Len())
+		}
+
+		for _, v := range []string{"f", "g", "h", "i"} {
+			ring.Push(v)
+		}
+		expected = []string{"g", "h", "i"}
+		if got := ring.Values(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Ring.Values() after several wraps got = %v, want %v", got, expected)
+		}
+	})
+
+	t.Run("returns a copy from Values", func(t *testing.T) {
+		ring := NewRing[int](2)
+		ring.Push(1)
+		values := ring.Values()
+		values[0] = 99
+		if got := ring.Values(); !reflect.DeepEqual(got, []int{1}) {
+			t.Errorf("Ring.Values() should return a copy, but the ring changed to %v", got)
+		}
+	})
+
+	t.Run("treats capacity less than 1 as 1", func(t *testing.T) {
+		ring := NewRing[int](0)
+		ring.Push(1)
+		ring.Push(2)
+		if got := ring.Values(); !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("Ring.Values() got = %v, want %v", got, []int{2})
+		}
+	})
+
+	t.Run("zero value discards pushes", func(t *testing.T) {
+		var ring Ring[int]
+		ring.Push(1)
+		if got := ring.Values(); ring.Len() != 0 || len(got) != 0 {
+			t.Errorf("zero Ring got Len() = %v, Values() = %v, want 0 and empty", ring.Len(), got)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "hash/maphash"
+
+// shardSeed seeds the key hashes used by ShardByKey. It is chosen once per process.
+var shardSeed = maphash.MakeSeed()
+
+// ShardRoundRobin distributes the elements of a slice across shards sub-slices by
+// position, so element i goes to shard i % shards. Elements keep their original order
+// within each shard. Every shard is a new non-nil slice, even when it receives no
+// elements. It returns nil for nil input or if shards is less than 1.
+func ShardRoundRobin[S ~[]E, E any](collection S, shards int) []S {
+	if collection == nil || shards < 1 {
+		return nil
+	}
+
+	result := make([]S, shards)
+	for shard := range result {
+		result[shard] = make(S, 0, (len(collection)-shard+shards-1)/shards)
+	}
+	for i, item := range collection {
+		result[i%shards] = append(result[i%shards], item)
+	}
+	return result
+}
+
+// ShardByKey distributes the elements of a slice across shards sub-slices by a hash of
+// the key returned by keySelector, so elements with equal keys always land in the same
+// shard. Elements keep their original order within each shard, and every shard is a new
+// non-nil slice. The hash uses hash/maphash with a per-process seed, so assignments are
+// stable within a process run but differ between runs and must not be persisted.
+// It returns nil for nil input or if shards is less than 1.
+func ShardByKey[S ~[]E, E any, K comparable](collection S, shards int, keySelector func(item E) K) []S {
+	if collection == nil || shards < 1 {
+		return nil
+	}
+
+	result := make([]S, shards)
+	for shard := range result {
+		result[shard] = S{}
+	}
+	for _, item := range collection {
+		shard := maphash.Comparable(shardSeed, keySelector(item)) % uint64(shards)
+		result[shard] = append(result[shard], item)
+	}
+	return result
+}
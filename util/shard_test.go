@@ -0,0 +1,119 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShardRoundRobin(t *testing.T) {
+	t.Run("distributes 7 elements across 3 shards", func(t *testing.T) {
+		expected := [][]int{{1, 4, 7}, {2, 5}, {3, 6}}
+		result := ShardRoundRobin([]int{1, 2, 3, 4, 5, 6, 7}, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ShardRoundRobin() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty non-nil shards when there are fewer elements", func(t *testing.T) {
+		result := ShardRoundRobin([]string{"a"}, 3)
+		if len(result) != 3 || !reflect.DeepEqual(result[0], []string{"a"}) {
+			t.Fatalf("ShardRoundRobin() got = %v, want [[a] [] []]", result)
+		}
+		for _, shard := range result[1:] {
+			if shard == nil || len(shard) != 0 {
+				t.Errorf("ShardRoundRobin() empty shard should be empty non-nil slice, but got %v", shard)
+			}
+		}
+	})
+
+	t.Run("does not share memory with the input", func(t *testing.T) {
+		input := []int{1, 2}
+		result := ShardRoundRobin(input, 1)
+		result[0][0] = 99
+		if input[0] != 1 {
+			t.Errorf("ShardRoundRobin() should copy elements, but the input was modified to %v", input)
+		}
+	})
+
+	t.Run("returns nil for shards less than 1", func(t *testing.T) {
+		if result := ShardRoundRobin([]int{1, 2}, 0); result != nil {
+			t.Errorf("ShardRoundRobin() with 0 shards should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := ShardRoundRobin([]int(nil), 2); result != nil {
+			t.Errorf("ShardRoundRobin() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestShardByKey(t *testing.T) {
+	type event struct {
+		user string
+		seq  int
+	}
+	userOf := func(item event) string { return item.user }
+
+	t.Run("equal keys always land in the same shard", func(t *testing.T) {
+		var input []event
+		for seq := range 60 {
+			input = append(input, event{user: string(rune('a' + seq%6)), seq: seq})
+		}
+		result := ShardByKey(input, 4, userOf)
+		if len(result) != 4 {
+			t.Fatalf("ShardByKey() got %d shards, want 4", len(result))
+		}
+
+		shardOf := make(map[string]int)
+		total := 0
+		for shard, items := range result {
+			total += len(items)
+			for _, item := range items {
+				if previous, ok := shardOf[item.user]; ok && previous != shard {
+					t.Errorf("ShardByKey() put key %q in shards %d and %d", item.user, previous, shard)
+				}
+				shardOf[item.user] = shard
+			}
+		}
+		if total != len(input) {
+			t.Errorf("ShardByKey() distributed %d elements, want %d", total, len(input))
+		}
+
+		again := ShardByKey(input, 4, userOf)
+		if !reflect.DeepEqual(again, result) {
+			t.Errorf("ShardByKey() is not stable within a run: got %v, then %v", result, again)
+		}
+	})
+
+	t.Run("preserves order within each shard", func(t *testing.T) {
+		input := []event{{"a", 1}, {"b", 2}, {"a", 3}, {"b", 4}, {"a", 5}}
+		for _, items := range ShardByKey(input, 3, userOf) {
+			for i := 1; i < len(items); i++ {
+				if items[i-1].seq > items[i].seq {
+					t.Errorf("ShardByKey() shard out of order: %v", items)
+				}
+			}
+		}
+	})
+
+	t.Run("returns empty non-nil shards", func(t *testing.T) {
+		for _, shard := range ShardByKey([]event{}, 2, userOf) {
+			if shard == nil || len(shard) != 0 {
+				t.Errorf("ShardByKey() shard should be empty non-nil slice, but got %v", shard)
+			}
+		}
+	})
+
+	t.Run("returns nil for shards less than 1", func(t *testing.T) {
+		if result := ShardByKey([]event{{"a", 1}}, 0, userOf); result != nil {
+			t.Errorf("ShardByKey() with 0 shards should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := ShardByKey([]event(nil), 2, userOf); result != nil {
+			t.Errorf("ShardByKey() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
@@ -3,6 +3,17 @@
 // `slices` package with additional functionality.
 package util
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrSizeMismatch is returned when group sizes are negative or do not add up to the
+// length of the slice being split.
+var ErrSizeMismatch = errors.New("util: group sizes do not match slice length")
+
 // Map applies a function to each element of a slice, returning a new slice
 // containing the results. It is a type-safe Go equivalent of Laravel's `Arr::map`.
 func Map[S ~[]E, E, R any](collection S, iteratee func(item E, index int) R) []R {
@@ -17,6 +28,41 @@ func Map[S ~[]E, E, R any](collection S, iteratee func(item E, index int) R) []R
 	return result
 }
 
+// MapInto applies a function to each element of a slice and writes the results into dst,
+// starting at index 0, so callers can reuse a buffer across calls. If dst has enough
+// capacity its backing array is reused; otherwise it grows via append. The returned slice
+// has exactly len(collection) elements and must be used in place of dst.
+// For a nil or empty collection it returns dst[:0], which is nil only when dst is nil.
+func MapInto[S ~[]E, E, R any](dst []R, collection S, iteratee func(item E, index int) R) []R {
+	dst = dst[:0]
+	for index, item := range collection {
+		dst = append(dst, iteratee(item, index))
+	}
+	return dst
+}
+
+// Tee2 computes two independent projections of a slice in a single pass, applying fa and
+// fb to each element in turn. Both results have the same length as collection and align
+// by index with it. It returns (nil, nil) for nil input and two empty (non-nil) slices for
+// empty input.
+func Tee2[S ~[]E, E any, A, B any](
+	collection S,
+	fa func(item E, index int) A,
+	fb func(item E, index int) B,
+) ([]A, []B) {
+	if collection == nil {
+		return nil, nil
+	}
+
+	resultA := make([]A, len(collection))
+	resultB := make([]B, len(collection))
+	for index, item := range collection {
+		resultA[index] = fa(item, index)
+		resultB[index] = fb(item, index)
+	}
+	return resultA, resultB
+}
+
 // Filter iterates over elements of a slice, returning a new slice containing all elements
 // for which the predicate function returns true. This is the Go equivalent of `Arr::where`.
 func Filter[S ~[]E, E any](collection S, predicate func(item E, index int) bool) S {
@@ -34,6 +80,132 @@ func Filter[S ~[]E, E any](collection S, predicate func(item E, index int) bool)
 	return result
 }
 
+// FilterMap transforms and filters a slice in a single pass. For each element, transform
+// returns the mapped value and whether to keep it; elements for which it returns false
+// are dropped. It returns nil for nil input and an empty (non-nil) slice otherwise.
+func FilterMap[S ~[]E, E, R any](collection S, transform func(item E, index int) (R, bool)) []R {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]R, 0, len(collection))
+	for index, item := range collection {
+		if mapped, ok := transform(item, index); ok {
+			result = append(result, mapped)
+		}
+	}
+	return result
+}
+
+// MapWhile transforms elements of a slice in order until transform reports false, and
+// returns the mapped values collected before that point. Unlike FilterMap, which skips
+// rejected elements, MapWhile stops at the first one. It returns nil for nil input and an
+// empty (non-nil) slice otherwise, including when the first element is rejected.
+func MapWhile[S ~[]E, E, R any](collection S, transform func(item E, index int) (R, bool)) []R {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]R, 0, len(collection))
+	for index, item := range collection {
+		mapped, ok := transform(item, index)
+		if !ok {
+			break
+		}
+		result = append(result, mapped)
+	}
+	return result
+}
+
+// MapUntil maps elements of a slice in order, letting the iteratee halt iteration early.
+// The bool returned by iteratee reports whether to continue. When it is false, the
+// result for that element is still included and iteration stops afterwards.
+//
+// This differs from MapWhile by one element: MapWhile drops the result for the element
+// that returns false, while MapUntil keeps it. That suits "stop once found" pipelines.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func MapUntil[S ~[]E, E, R any](collection S, iteratee func(item E, index int) (R, bool)) []R {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]R, 0, len(collection))
+	for index, item := range collection {
+		mapped, next := iteratee(item, index)
+		result = append(result, mapped)
+		if !next {
+			break
+		}
+	}
+	return result
+}
+
+// MapResults applies a fallible transform to every element and collects both outcomes
+// instead of stopping at the first failure. results holds the successful values in input
+// order, and errs holds the failures in input order, each wrapped with the index of the
+// element that produced it so errors.Is and errors.As still match the original error.
+// It returns (nil, nil) for nil input; otherwise results is non-nil, and errs is nil when
+// every element succeeds.
+func MapResults[S ~[]E, E, R any](
+	collection S,
+	transform func(item E, index int) (R, error),
+) (results []R, errs []error) {
+	if collection == nil {
+		return nil, nil
+	}
+
+	results = make([]R, 0, len(collection))
+	for index, item := range collection {
+		mapped, err := transform(item, index)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("element %d: %w", index, err))
+			continue
+		}
+		results = append(results, mapped)
+	}
+	return results, errs
+}
+
+// RemoveWhere returns a new slice without the elements for which the predicate returns
+// true, along with the number of elements removed. It is the removal-oriented counterpart
+// of Filter, useful when the caller needs to report how much was dropped.
+// It returns (nil, 0) for nil input; otherwise the result is non-nil, even when every
+// element is removed.
+func RemoveWhere[S ~[]E, E any](collection S, predicate func(item E, index int) bool) (result S, removed int) {
+	if collection == nil {
+		return nil, 0
+	}
+
+	result = make(S, 0, len(collection))
+	for index, item := range collection {
+		if predicate(item, index) {
+			removed++
+			continue
+		}
+		result = append(result, item)
+	}
+	return result, removed
+}
+
+// Compress returns a new slice containing the elements of collection at the positions
+// where the parallel mask is true, in order. A mask shorter than the collection, including
+// a nil mask, treats the missing entries as false, so those elements are dropped; extra
+// mask entries beyond the collection are ignored. It returns nil for nil input and an
+// empty (non-nil) slice otherwise.
+func Compress[S ~[]E, E any](collection S, mask []bool) S {
+	if collection == nil {
+		return nil
+	}
+
+	result := make(S, 0, min(len(collection), len(mask)))
+	for i := range min(len(collection), len(mask)) {
+		if mask[i] {
+			result = append(result, collection[i])
+		}
+	}
+	return result
+}
+
 // Unique returns a new slice with duplicate values removed.
 // The order of elements is preserved from the first time they appear in the collection.
 // It requires the element type to be comparable.
@@ -54,6 +226,86 @@ func Unique[S ~[]E, E comparable](collection S) S {
 	return result
 }
 
+// compositeKey chains selector outputs into a single comparable map key for UniqueByKeys.
+type compositeKey struct {
+	prev  any
+	value any
+}
+
+// UniqueByKeys returns a new slice with duplicates removed, where two elements are
+// duplicates when every key selector returns equal values for them. The tuple of selector
+// outputs is the identity, so no composite string key has to be built by hand. The first
+// occurrence of each identity is kept, in order. With no selectors every element has the
+// same (empty) identity, so only the first element is kept.
+//
+// Every selector must return a comparable value, such as a string, number, or struct of
+// comparable fields; a non-comparable value like a slice or map causes a runtime panic.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func UniqueByKeys[S ~[]E, E any](collection S, keySelectors ...func(item E) any) S {
+	if collection == nil {
+		return nil
+	}
+
+	seen := make(map[any]struct{}, len(collection))
+	result := make(S, 0, len(collection))
+	for _, item := range collection {
+		var key any = compositeKey{}
+		for _, selector := range keySelectors {
+			key = compositeKey{prev: key, value: selector(item)}
+		}
+		if _, exists := seen[key]; !exists {
+			seen[key] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// UniqueSorted returns a new slice with duplicate values removed, assuming the collection
+// is sorted so that equal values are adjacent. Unlike Unique it needs no map, using O(1)
+// extra memory beyond the result. If the input is not sorted, only adjacent duplicates are
+// removed and the result may still contain repeated values.
+func UniqueSorted[S ~[]E, E comparable](collection S) S {
+	if collection == nil {
+		return nil
+	}
+
+	var result S
+	for i, item := range collection {
+		if i == 0 || item != collection[i-1] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// KeepLastUnique returns up to capacity distinct values, ordered by their last occurrence
+// in the collection. It behaves like a bounded recent-history list: when more than
+// capacity distinct values are present, those whose last occurrence is oldest are evicted.
+// It returns an empty (non-nil) slice if capacity is less than 1 and nil for nil input.
+func KeepLastUnique[S ~[]E, E comparable](collection S, capacity int) S {
+	if collection == nil {
+		return nil
+	}
+	if capacity < 1 {
+		return S{}
+	}
+
+	// Walk backwards so the first time a value is seen is its last occurrence
+	seen := make(map[E]struct{}, capacity)
+	result := make(S, 0, min(capacity, len(collection)))
+	for i := len(collection) - 1; i >= 0 && len(result) < capacity; i-- {
+		item := collection[i]
+		if _, exists := seen[item]; !exists {
+			seen[item] = struct{}{}
+			result = append(result, item)
+		}
+	}
+
+	slices.Reverse(result)
+	return result
+}
+
 // Pluck creates a slice of a single property from a slice of structs or maps.
 // It is a type-safe Go equivalent of Laravel's `Arr::pluck`.
 func Pluck[S ~[]E, E, R any](collection S, propertyGetter func(item E) R) []R {
@@ -94,6 +346,97 @@ func Chunk[S ~[]E, E any](collection S, size int) []S {
 	return chunks
 }
 
+// BatchRanges returns the [start, end) index ranges that split a sequence of the given
+// length into batches of size elements, with the last batch holding any remainder. It
+// mirrors Chunk without materializing sub-slices, so callers can slice their own data.
+// It returns nil if size is less than 1 and an empty (non-nil) slice if length is less
+// than 1.
+func BatchRanges(length, size int) [][2]int {
+	if size < 1 {
+		return nil
+	}
+	if length < 1 {
+		return [][2]int{}
+	}
+
+	ranges := make([][2]int, 0, (length+size-1)/size)
+	for start := 0; start < length; start += size {
+		ranges = append(ranges, [2]int{start, min(start+size, length)})
+	}
+	return ranges
+}
+
+// ChunkBySize splits a slice into chunks whose cumulative size, as reported by sizeOf,
+// does not exceed maxBytes. Elements are added to the current chunk until adding the next
+// one would exceed maxBytes, at which point a new chunk is started.
+// A single element larger than maxBytes is placed alone in its own chunk rather than
+// being dropped. If maxBytes is less than 1 or the input is nil, it returns nil.
+func ChunkBySize[S ~[]E, E any](collection S, maxBytes int, sizeOf func(item E) int) []S {
+	if collection == nil || maxBytes < 1 {
+		return nil
+	}
+
+	chunks := []S{}
+	start, current := 0, 0
+	for i, item := range collection {
+		size := sizeOf(item)
+		if i > start && current+size > maxBytes {
+			chunks = append(chunks, collection[start:i])
+			start, current = i, 0
+		}
+		current += size
+	}
+
+	if start < len(collection) {
+		chunks = append(chunks, collection[start:])
+	}
+	return chunks
+}
+
+// BinPack distributes the elements of a slice into as few bins as practical, such that the
+// total weight of each bin does not exceed capacity. It uses the first-fit-decreasing
+// heuristic: elements are considered from heaviest to lightest and each is placed in the
+// first bin with enough remaining room, opening a new bin when none fits. The result is
+// near-optimal but not guaranteed to be minimal.
+//
+// An element heavier than capacity is placed alone in its own bin. Bins are returned in the
+// order they were opened, each holding its elements in placement order. Every element is
+// placed exactly once. If capacity is less than 1 or the input is nil, it returns nil.
+func BinPack[S ~[]E, E any](collection S, capacity int, weight func(item E) int) []S {
+	if collection == nil || capacity < 1 {
+		return nil
+	}
+
+	weights := make([]int, len(collection))
+	order := make([]int, len(collection))
+	for i, item := range collection {
+		weights[i] = weight(item)
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(weights[b], weights[a])
+	})
+
+	bins := []S{}
+	var remaining []int
+	for _, i := range order {
+		placed := false
+		for b := range bins {
+			if weights[i] <= remaining[b] {
+				bins[b] = append(bins[b], collection[i])
+				remaining[b] -= weights[i]
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			bins = append(bins, S{collection[i]})
+			remaining = append(remaining, max(capacity-weights[i], 0))
+		}
+	}
+	return bins
+}
+
 // Flatten transforms a slice of slices into a single flattened slice.
 func Flatten[E any](collections [][]E) []E {
 	if collections == nil {
@@ -113,6 +456,168 @@ func Flatten[E any](collections [][]E) []E {
 	return result
 }
 
+// FlattenLimit transforms a slice of slices into a single flattened slice like Flatten,
+// but stops once limit elements have been emitted, even partway through an inner slice.
+// Only the returned prefix is allocated, which keeps bounded responses cheap when the
+// nested input is large. It returns nil for nil input and an empty (non-nil) slice if
+// limit is less than 1.
+func FlattenLimit[E any](collections [][]E, limit int) []E {
+	if collections == nil {
+		return nil
+	}
+	if limit < 1 {
+		return []E{}
+	}
+
+	totalLen := 0
+	for _, collection := range collections {
+		if totalLen += len(collection); totalLen >= limit {
+			break
+		}
+	}
+
+	result := make([]E, 0, min(totalLen, limit))
+	for _, collection := range collections {
+		remaining := limit - len(result)
+		if remaining == 0 {
+			break
+		}
+		result = append(result, collection[:min(len(collection), remaining)]...)
+	}
+	return result
+}
+
+// FlattenSeparated transforms a slice of slices into a single flattened slice, inserting
+// sep between consecutive groups but never within a group or at either end. Like
+// strings.Join, an empty inner slice still counts as a group, so it produces adjacent
+// separators. It returns nil for nil input and an empty (non-nil) slice for empty input.
+func FlattenSeparated[E any](collections [][]E, sep E) []E {
+	if collections == nil {
+		return nil
+	}
+	if len(collections) == 0 {
+		return []E{}
+	}
+
+	totalLen := len(collections) - 1
+	for _, collection := range collections {
+		totalLen += len(collection)
+	}
+
+	result := make([]E, 0, totalLen)
+	for i, collection := range collections {
+		if i > 0 {
+			result = append(result, sep)
+		}
+		result = append(result, collection...)
+	}
+	return result
+}
+
+// FlattenUnique transforms a slice of slices into a single flattened slice containing
+// each distinct element once, in order of first appearance across all groups. It is
+// equivalent to Unique(Flatten(collections)) but makes a single pass.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func FlattenUnique[E comparable](collections [][]E) []E {
+	if collections == nil {
+		return nil
+	}
+
+	seen := make(map[E]struct{})
+	result := []E{}
+	for _, collection := range collections {
+		for _, item := range collection {
+			if _, ok := seen[item]; ok {
+				continue
+			}
+			seen[item] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// FlattenGroupBy flattens a slice of slices and groups the elements by the result of the
+// keySelector function in a single pass, equivalent to GroupBy(Flatten(collections), ...)
+// without the intermediate slice. Within each group, elements keep their flattened order.
+// It returns nil for nil input and an empty (non-nil) map for empty input.
+func FlattenGroupBy[E any, K comparable](collections [][]E, keySelector func(item E) K) map[K][]E {
+	if collections == nil {
+		return nil
+	}
+
+	result := make(map[K][]E)
+	for _, collection := range collections {
+		for _, item := range collection {
+			key := keySelector(item)
+			result[key] = append(result[key], item)
+		}
+	}
+	return result
+}
+
+// FlattenIndexed transforms a slice of slices into a single flattened slice and
+// additionally reports, for each flattened element, the index of the inner slice
+// it originated from. Both returned slices have the same length.
+// If collections is nil, it returns (nil, nil).
+func FlattenIndexed[E any](collections [][]E) (flat []E, groupIndex []int) {
+	if collections == nil {
+		return nil, nil
+	}
+
+	// Calculate total length to avoid reallocations
+	totalLen := 0
+	for _, collection := range collections {
+		totalLen += len(collection)
+	}
+
+	flat = make([]E, 0, totalLen)
+	groupIndex = make([]int, 0, totalLen)
+	for group, collection := range collections {
+		for _, item := range collection {
+			flat = append(flat, item)
+			groupIndex = append(groupIndex, group)
+		}
+	}
+	return flat, groupIndex
+}
+
+// Unflatten splits a flat slice into consecutive groups of the given sizes, reversing
+// Flatten when the original group lengths are known. Zero sizes yield empty groups.
+// Each group is a capacity-limited view into flat rather than a copy.
+//
+// It returns an error wrapping ErrSizeMismatch if any size is negative or the sizes do not
+// add up to len(flat). It returns (nil, nil) when both flat and sizes are nil.
+func Unflatten[E any](flat []E, sizes []int) ([][]E, error) {
+	if flat == nil && sizes == nil {
+		return nil, nil
+	}
+
+	total := 0
+	for i, size := range sizes {
+		if size < 0 {
+			return nil, fmt.Errorf("%w: negative size %d at index %d", ErrSizeMismatch, size, i)
+		}
+		// Comparing against the remaining length keeps the running total from overflowing.
+		if size > len(flat)-total {
+			return nil, fmt.Errorf("%w: sizes exceed %d elements at index %d", ErrSizeMismatch, len(flat), i)
+		}
+		total += size
+	}
+	if total != len(flat) {
+		return nil, fmt.Errorf("%w: sizes sum to %d, slice has %d elements", ErrSizeMismatch, total, len(flat))
+	}
+
+	result := make([][]E, len(sizes))
+	start := 0
+	for i, size := range sizes {
+		end := start + size
+		result[i] = flat[start:end:end]
+		start = end
+	}
+	return result, nil
+}
+
 // GroupBy groups the elements of a slice by the result of the keySelector function.
 // It returns a map where each key is the result of the keySelector function and
 // the value is a slice of all elements that produced that key.
@@ -129,6 +634,143 @@ func GroupBy[S ~[]E, E any, K comparable](collection S, keySelector func(item E)
 	return result
 }
 
+// GroupByOrdered groups the elements of a slice like GroupBy and additionally returns the
+// keys in the order they were first seen, so groups can be iterated deterministically
+// without requiring the key type to be ordered.
+// It returns (nil, nil) for nil input.
+func GroupByOrdered[S ~[]E, E any, K comparable](
+	collection S,
+	keySelector func(item E) K,
+) (groups map[K]S, keyOrder []K) {
+	if collection == nil {
+		return nil, nil
+	}
+
+	groups = make(map[K]S)
+	keyOrder = []K{}
+	for _, item := range collection {
+		key := keySelector(item)
+		if _, exists := groups[key]; !exists {
+			keyOrder = append(keyOrder, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+	return groups, keyOrder
+}
+
+// GroupBySorted groups the elements of a slice like GroupBy and then sorts each group with
+// less, which reports whether a should come before b. The sort is stable, so elements that
+// compare equal keep their original relative order. Groups are new slices and the input is
+// not modified. It returns nil for nil input and an empty (non-nil) map for empty input.
+func GroupBySorted[S ~[]E, E any, K comparable](
+	collection S,
+	keySelector func(item E) K,
+	less func(a, b E) bool,
+) map[K]S {
+	groups := GroupBy(collection, keySelector)
+	for _, group := range groups {
+		slices.SortStableFunc(group, func(a, b E) int {
+			switch {
+			case less(a, b):
+				return -1
+			case less(b, a):
+				return 1
+			default:
+				return 0
+			}
+		})
+	}
+	return groups
+}
+
+// GroupByCapped groups the elements of a slice like GroupBy, but each group holds at most
+// capacity elements: the first capacity elements for a key are grouped, and any further
+// elements go to overflow in their original order. A capacity less than 1 sends every
+// element to overflow. It returns (nil, nil) for nil input; otherwise groups and overflow
+// are both non-nil.
+func GroupByCapped[S ~[]E, E any, K comparable](
+	collection S,
+	keySelector func(item E) K,
+	capacity int,
+) (groups map[K]S, overflow S) {
+	if collection == nil {
+		return nil, nil
+	}
+
+	groups = make(map[K]S)
+	overflow = S{}
+	for _, item := range collection {
+		key := keySelector(item)
+		if len(groups[key]) >= capacity {
+			overflow = append(overflow, item)
+			continue
+		}
+		groups[key] = append(groups[key], item)
+	}
+	return groups, overflow
+}
+
+// GroupByReduce groups the elements of a slice by the result of the keySelector function
+// and folds each group into a single value. Each group starts from initial and is reduced
+// with reducer in the order the elements appear in the collection.
+// It returns nil for nil input and an empty (non-nil) map for empty input.
+func GroupByReduce[S ~[]E, E any, K comparable, R any](
+	collection S,
+	keySelector func(item E) K,
+	initial R,
+	reducer func(acc R, item E) R,
+) map[K]R {
+	if collection == nil {
+		return nil
+	}
+
+	result := make(map[K]R)
+	for _, item := range collection {
+		key := keySelector(item)
+		acc, exists := result[key]
+		if !exists {
+			acc = initial
+		}
+		result[key] = reducer(acc, item)
+	}
+	return result
+}
+
+// TransformGroups maps each group of a grouped map, such as the result of GroupBy, to a
+// single value and returns a new map with the same keys. The input map is not modified.
+// It returns nil for nil input and an empty (non-nil) map for empty input.
+func TransformGroups[K comparable, S ~[]E, E any, R any](groups map[K]S, transform func(group S) R) map[K]R {
+	if groups == nil {
+		return nil
+	}
+
+	result := make(map[K]R, len(groups))
+	for key, group := range groups {
+		result[key] = transform(group)
+	}
+	return result
+}
+
+// Sessionize assigns each element a 0-based session index, starting at 0 and incrementing
+// whenever newSession(prev, cur) reports true for an element and its predecessor. The
+// result has the same length as collection, so labels stay aligned by position, which
+// suits sessionization of event streams by a gap predicate.
+// It returns nil for nil input and an empty (non-nil) slice for empty input.
+func Sessionize[S ~[]E, E any](collection S, newSession func(prev, cur E) bool) []int {
+	if collection == nil {
+		return nil
+	}
+
+	result := make([]int, len(collection))
+	for i := 1; i < len(collection); i++ {
+		result[i] = result[i-1]
+		if newSession(collection[i-1], collection[i]) {
+			result[i]++
+		}
+	}
+	return result
+}
+
 // Reduce applies a function against an accumulator and each element in the slice
 // to reduce it to a single value.
 func Reduce[S ~[]E, E, R any](collection S, initialValue R, reducer func(acc R, item E, index int) R) R {
@@ -143,6 +785,28 @@ func Reduce[S ~[]E, E, R any](collection S, initialValue R, reducer func(acc R,
 	return result
 }
 
+// FoldMap threads a state through the slice like Reduce while also emitting one output per
+// element, in the style of a Mealy machine. For each element, step receives the current
+// state and returns the next state and the output for that element. It returns the final
+// state and the outputs in input order: (initial, nil) for nil input and (initial, empty
+// non-nil slice) for empty input.
+func FoldMap[S ~[]E, E, St, R any](
+	collection S,
+	initial St,
+	step func(state St, item E, index int) (St, R),
+) (St, []R) {
+	if collection == nil {
+		return initial, nil
+	}
+
+	state := initial
+	outputs := make([]R, len(collection))
+	for i, item := range collection {
+		state, outputs[i] = step(state, item, i)
+	}
+	return state, outputs
+}
+
 // Intersect returns a slice containing all elements that are present in all given slices.
 // The order of elements is preserved from the first slice.
 func Intersect[S ~[]E, E comparable](collections ...S) S {
@@ -187,3 +851,45 @@ func Intersect[S ~[]E, E comparable](collections ...S) S {
 
 	return result
 }
+
+// IntersectMultiset returns a slice containing the elements common to all given slices,
+// preserving multiplicity: each value appears min(count across all slices) times.
+// Unlike Intersect, which treats its inputs as sets and removes duplicates, this treats
+// them as multisets. The order of elements follows their appearance in the first slice.
+func IntersectMultiset[S ~[]E, E comparable](collections ...S) S {
+	if len(collections) == 0 {
+		return nil
+	}
+
+	// Start from the counts of the first slice and lower them to the minimum
+	// count observed in every other slice
+	remaining := make(map[E]int, len(collections[0]))
+	for _, item := range collections[0] {
+		remaining[item]++
+	}
+
+	for _, collection := range collections[1:] {
+		counts := make(map[E]int, len(collection))
+		for _, item := range collection {
+			counts[item]++
+		}
+		for item, count := range remaining {
+			remaining[item] = min(count, counts[item])
+		}
+	}
+
+	var result S
+	for _, item := range collections[0] {
+		if remaining[item] > 0 {
+			result = append(result, item)
+			remaining[item]--
+		}
+	}
+
+	// Return an empty slice (not nil) if no common elements were found
+	if len(result) == 0 && len(collections[0]) > 0 {
+		return S{}
+	}
+
+	return result
+}
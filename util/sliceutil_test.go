@@ -1,8 +1,12 @@
 package util
 
 import (
+	"errors"
+	"math"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -27,6 +31,88 @@ func TestMap(t *testing.T) {
 	})
 }
 
+func TestMapInto(t *testing.T) {
+	double := func(item int, _ int) int { return item * 2 }
+
+	t.Run("reuses the destination when capacity suffices", func(t *testing.T) {
+		dst := make([]int, 5, 8)
+		result := MapInto(dst, []int{1, 2, 3}, double)
+		expected := []int{2, 4, 6}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapInto() got = %v, want %v", result, expected)
+		}
+		if &result[0] != &dst[0] {
+			t.Errorf("MapInto() should reuse the destination backing array")
+		}
+	})
+
+	t.Run("grows the destination when too small", func(t *testing.T) {
+		dst := make([]int, 0, 1)
+		result := MapInto(dst, []int{1, 2, 3}, double)
+		expected := []int{2, 4, 6}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapInto() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns dst[:0] for nil collection", func(t *testing.T) {
+		dst := make([]int, 3)
+		result := MapInto(dst, []int(nil), double)
+		if result == nil || len(result) != 0 || cap(result) != 3 {
+			t.Errorf("MapInto() on nil collection should return dst[:0], got %v (cap %d)", result, cap(result))
+		}
+		if result := MapInto(nil, []int(nil), double); result != nil {
+			t.Errorf("MapInto() with nil dst and nil collection should return nil, got %v", result)
+		}
+	})
+}
+
+func TestTee2(t *testing.T) {
+	type product struct {
+		name  string
+		price int
+	}
+	name := func(item product, _ int) string { return item.name }
+	label := func(item product, index int) string { return strconv.Itoa(index) + ":" + strconv.Itoa(item.price) }
+
+	t.Run("returns two outputs aligned by index", func(t *testing.T) {
+		input := []product{{"pen", 2}, {"book", 12}, {"bag", 30}}
+		names, labels := Tee2(input, name, label)
+		if expected := []string{"pen", "book", "bag"}; !reflect.DeepEqual(names, expected) {
+			t.Errorf("Tee2() first output got = %v, want %v", names, expected)
+		}
+		if expected := []string{"0:2", "1:12", "2:30"}; !reflect.DeepEqual(labels, expected) {
+			t.Errorf("Tee2() second output got = %v, want %v", labels, expected)
+		}
+	})
+
+	t.Run("iterates the source once", func(t *testing.T) {
+		visits := 0
+		counted := func(item int, _ int) int {
+			visits++
+			return item
+		}
+		Tee2([]int{1, 2, 3}, counted, func(item int, _ int) bool { return item > 1 })
+		if visits != 3 {
+			t.Errorf("Tee2() called fa %d times, want 3", visits)
+		}
+	})
+
+	t.Run("returns empty slices for empty input", func(t *testing.T) {
+		names, labels := Tee2([]product{}, name, label)
+		if names == nil || len(names) != 0 || labels == nil || len(labels) != 0 {
+			t.Errorf("Tee2() on empty slice got = (%v, %v), want two empty slices", names, labels)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		names, labels := Tee2([]product(nil), name, label)
+		if names != nil || labels != nil {
+			t.Errorf("Tee2() on nil slice got = (%v, %v), want (nil, nil)", names, labels)
+		}
+	})
+}
+
 func TestFilter(t *testing.T) {
 	t.Run("filters for even numbers", func(t *testing.T) {
 		input := []int{1, 2, 3, 4, 5, 6}
@@ -56,6 +142,277 @@ func TestFilter(t *testing.T) {
 	})
 }
 
+func TestFilterMap(t *testing.T) {
+	parse := func(item string, _ int) (int, bool) {
+		n, err := strconv.Atoi(item)
+		return n, err == nil
+	}
+
+	t.Run("keeps only strings that parse as ints", func(t *testing.T) {
+		input := []string{"1", "x", "3", "", "42"}
+		expected := []int{1, 3, 42}
+		result := FilterMap(input, parse)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("passes the index to the transform", func(t *testing.T) {
+		input := []string{"a", "b", "c", "d"}
+		expected := []string{"a0", "c2"}
+		result := FilterMap(input, func(item string, index int) (string, bool) {
+			return item + strconv.Itoa(index), index%2 == 0
+		})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when nothing is kept", func(t *testing.T) {
+		input := []string{"x", "y"}
+		expected := []int{}
+		result := FilterMap(input, parse)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []string{}
+		expected := []int{}
+		result := FilterMap(input, parse)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []string
+		result := FilterMap(input, parse)
+		if result != nil {
+			t.Errorf("FilterMap() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMapWhile(t *testing.T) {
+	parseDigit := func(item string, _ int) (int, bool) {
+		if len(item) != 1 || item[0] < '0' || item[0] > '9' {
+			return 0, false
+		}
+		return int(item[0] - '0'), true
+	}
+
+	t.Run("stops mapping mid-slice", func(t *testing.T) {
+		calls := 0
+		counting := func(item string, index int) (int, bool) {
+			calls++
+			return parseDigit(item, index)
+		}
+		expected := []int{1, 2}
+		result := MapWhile([]string{"1", "2", "x", "3"}, counting)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapWhile() got = %v, want %v", result, expected)
+		}
+		if calls != 3 {
+			t.Errorf("MapWhile() should stop at the first failure, but made %d calls, want 3", calls)
+		}
+	})
+
+	t.Run("maps the whole slice when every element succeeds", func(t *testing.T) {
+		expected := []int{4, 5, 6}
+		result := MapWhile([]string{"4", "5", "6"}, parseDigit)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapWhile() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice on immediate failure", func(t *testing.T) {
+		result := MapWhile([]string{"x", "1"}, parseDigit)
+		if result == nil || len(result) != 0 {
+			t.Errorf("MapWhile() should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		if result := MapWhile([]string(nil), parseDigit); result != nil {
+			t.Errorf("MapWhile() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMapUntil(t *testing.T) {
+	upperUntilStop := func(item string, _ int) (string, bool) {
+		return strings.ToUpper(item), item != "stop"
+	}
+
+	t.Run("includes the element that triggers the stop", func(t *testing.T) {
+		expected := []string{"A", "B", "STOP"}
+		result := MapUntil([]string{"a", "b", "stop", "c"}, upperUntilStop)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapUntil() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("keeps one more result than MapWhile", func(t *testing.T) {
+		input := []string{"a", "stop", "b"}
+		until := MapUntil(input, upperUntilStop)
+		while := MapWhile(input, upperUntilStop)
+		if len(until) != len(while)+1 {
+			t.Errorf("MapUntil() got %v, MapWhile() got %v, want MapUntil to keep one more", until, while)
+		}
+	})
+
+	t.Run("maps the whole slice when never stopped", func(t *testing.T) {
+		expected := []string{"X", "Y"}
+		result := MapUntil([]string{"x", "y"}, upperUntilStop)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapUntil() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := MapUntil([]string{}, upperUntilStop)
+		if result == nil || len(result) != 0 {
+			t.Errorf("MapUntil() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := MapUntil([]string(nil), upperUntilStop); result != nil {
+			t.Errorf("MapUntil() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMapResults(t *testing.T) {
+	errOdd := errors.New("odd")
+	halve := func(item int, _ int) (int, error) {
+		if item%2 != 0 {
+			return 0, errOdd
+		}
+		return item / 2, nil
+	}
+
+	t.Run("collects successes and failures", func(t *testing.T) {
+		results, errs := MapResults([]int{2, 3, 4, 5, 6}, halve)
+		if expected := []int{1, 2, 3}; !reflect.DeepEqual(results, expected) {
+			t.Errorf("MapResults() results got = %v, want %v", results, expected)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("MapResults() got %d errors, want 2: %v", len(errs), errs)
+		}
+		for i, want := range []string{"element 1: odd", "element 3: odd"} {
+			if !errors.Is(errs[i], errOdd) || errs[i].Error() != want {
+				t.Errorf("MapResults() error %d got = %v, want %q wrapping %v", i, errs[i], want, errOdd)
+			}
+		}
+	})
+
+	t.Run("returns nil errors when everything succeeds", func(t *testing.T) {
+		results, errs := MapResults([]int{2, 4}, halve)
+		if !reflect.DeepEqual(results, []int{1, 2}) || errs != nil {
+			t.Errorf("MapResults() got = (%v, %v), want ([1 2], nil)", results, errs)
+		}
+	})
+
+	t.Run("returns empty results when everything fails", func(t *testing.T) {
+		results, errs := MapResults([]int{1, 3}, halve)
+		if results == nil || len(results) != 0 || len(errs) != 2 {
+			t.Errorf("MapResults() got = (%v, %v), want empty results and 2 errors", results, errs)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		results, errs := MapResults([]int(nil), halve)
+		if results != nil || errs != nil {
+			t.Errorf("MapResults() on nil slice got = (%v, %v), want (nil, nil)", results, errs)
+		}
+	})
+}
+
+func TestRemoveWhere(t *testing.T) {
+	isEven := func(item int, _ int) bool { return item%2 == 0 }
+
+	t.Run("removes matching elements and counts them", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := []int{1, 3, 5}
+		result, removed := RemoveWhere(input, isEven)
+		if !reflect.DeepEqual(result, expected) || removed != 2 {
+			t.Errorf("RemoveWhere() got = (%v, %d), want (%v, %d)", result, removed, expected, 2)
+		}
+	})
+
+	t.Run("removes nothing when no element matches", func(t *testing.T) {
+		input := []int{1, 3, 5}
+		expected := []int{1, 3, 5}
+		result, removed := RemoveWhere(input, isEven)
+		if !reflect.DeepEqual(result, expected) || removed != 0 {
+			t.Errorf("RemoveWhere() got = (%v, %d), want (%v, %d)", result, removed, expected, 0)
+		}
+	})
+
+	t.Run("removes all elements when every element matches", func(t *testing.T) {
+		input := []int{2, 4, 6}
+		expected := []int{}
+		result, removed := RemoveWhere(input, isEven)
+		if !reflect.DeepEqual(result, expected) || removed != 3 {
+			t.Errorf("RemoveWhere() got = (%v, %d), want (%v, %d)", result, removed, expected, 3)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result, removed := RemoveWhere(input, isEven)
+		if result != nil || removed != 0 {
+			t.Errorf("RemoveWhere() on nil slice should return (nil, 0), got (%v, %d)", result, removed)
+		}
+	})
+}
+
+func TestCompress(t *testing.T) {
+	t.Run("keeps elements where the mask is true", func(t *testing.T) {
+		expected := []int{10, 30}
+		result := Compress([]int{10, 20, 30, 40}, []bool{true, false, true, false})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Compress() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("keeps every element with an all-true mask", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		result := Compress(input, []bool{true, true, true})
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("Compress() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("treats missing mask entries as false", func(t *testing.T) {
+		expected := []int{1}
+		result := Compress([]int{1, 2, 3}, []bool{true})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Compress() with short mask got = %v, want %v", result, expected)
+		}
+		if result := Compress([]int{1, 2}, nil); result == nil || len(result) != 0 {
+			t.Errorf("Compress() with nil mask should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("ignores extra mask entries", func(t *testing.T) {
+		expected := []int{2}
+		result := Compress([]int{1, 2}, []bool{false, true, true, true})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Compress() with long mask got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := Compress([]int(nil), []bool{true}); result != nil {
+			t.Errorf("Compress() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestUnique(t *testing.T) {
 	t.Run("removes duplicates and preserves order", func(t *testing.T) {
 		input := []string{"a", "b", "a", "c", "b", "d", "a"}
@@ -92,6 +449,130 @@ func TestUnique(t *testing.T) {
 	})
 }
 
+func TestUniqueByKeys(t *testing.T) {
+	type record struct {
+		region string
+		tier   int
+		name   string
+	}
+	byRegion := func(item record) any { return item.region }
+	byTier := func(item record) any { return item.tier }
+
+	t.Run("dedupes by the pair of keys", func(t *testing.T) {
+		input := []record{
+			{"eu", 1, "a"},
+			{"eu", 2, "b"},
+			{"us", 1, "c"},
+			{"eu", 1, "d"},
+			{"us", 2, "e"},
+			{"us", 1, "f"},
+		}
+		expected := []record{{"eu", 1, "a"}, {"eu", 2, "b"}, {"us", 1, "c"}, {"us", 2, "e"}}
+		result := UniqueByKeys(input, byRegion, byTier)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("UniqueByKeys() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("distinguishes keys of different types with equal text", func(t *testing.T) {
+		input := []record{{"1", 1, "a"}, {"1", 1, "b"}}
+		asString := func(item record) any { return item.region }
+		asInt := func(item record) any { return item.tier }
+		result := UniqueByKeys(input, asString, asInt)
+		if !reflect.DeepEqual(result, input[:1]) {
+			t.Errorf("UniqueByKeys() got = %v, want %v", result, input[:1])
+		}
+	})
+
+	t.Run("keeps only the first element without selectors", func(t *testing.T) {
+		input := []record{{"eu", 1, "a"}, {"us", 2, "b"}}
+		result := UniqueByKeys(input)
+		if !reflect.DeepEqual(result, input[:1]) {
+			t.Errorf("UniqueByKeys() got = %v, want %v", result, input[:1])
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := UniqueByKeys([]record{}, byRegion)
+		if result == nil || len(result) != 0 {
+			t.Errorf("UniqueByKeys() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := UniqueByKeys([]record(nil), byRegion); result != nil {
+			t.Errorf("UniqueByKeys() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestUniqueSorted(t *testing.T) {
+	t.Run("removes runs of duplicates from a sorted slice", func(t *testing.T) {
+		input := []int{1, 1, 1, 2, 3, 3, 4, 5, 5, 5, 5}
+		expected := []int{1, 2, 3, 4, 5}
+		result := UniqueSorted(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("UniqueSorted() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("only removes adjacent duplicates from unsorted input", func(t *testing.T) {
+		// Behavior on unsorted input is not a full dedup; document it by example.
+		input := []string{"a", "a", "b", "a"}
+		expected := []string{"a", "b", "a"}
+		result := UniqueSorted(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("UniqueSorted() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil slice", func(t *testing.T) {
+		var input []int
+		result := UniqueSorted(input)
+		if result != nil {
+			t.Errorf("UniqueSorted() on nil slice should return nil, got %v", result)
+		}
+	})
+}
+
+func TestKeepLastUnique(t *testing.T) {
+	t.Run("evicts the oldest distinct value", func(t *testing.T) {
+		input := []string{"a", "b", "c", "a", "d", "b"}
+		// Last occurrences: c@2, a@3, d@4, b@5 - "c" is the oldest and is evicted.
+		expected := []string{"a", "d", "b"}
+		result := KeepLastUnique(input, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("KeepLastUnique() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("keeps all distinct values when under capacity", func(t *testing.T) {
+		input := []int{1, 2, 1, 3}
+		expected := []int{2, 1, 3}
+		result := KeepLastUnique(input, 10)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("KeepLastUnique() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when capacity <= 0", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		expected := []int{}
+		result := KeepLastUnique(input, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("KeepLastUnique() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := KeepLastUnique(input, 3)
+		if result != nil {
+			t.Errorf("KeepLastUnique() on nil slice should return nil, got %v", result)
+		}
+	})
+}
+
 func TestPluck(t *testing.T) {
 	type User struct {
 		ID   int
@@ -108,117 +589,516 @@ func TestPluck(t *testing.T) {
 			return item.Name
 		})
 		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Pluck() for Name got = %v, want %v", result, expected)
+			t.Errorf("Pluck() for Name got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("plucks an int property from a struct slice", func(t *testing.T) {
+		input := []User{
+			{ID: 1, Name: "Alice"},
+			{ID: 2, Name: "Bob"},
+			{ID: 3, Name: "Charlie"},
+		}
+		expected := []int{1, 2, 3}
+		result := Pluck(input, func(item User) int {
+			return item.ID
+		})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Pluck() for ID got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		var input []User // actually nil leads to nil return; test both cases
+		resultNil := Pluck(input, func(item User) int { return item.ID })
+		if resultNil != nil {
+			t.Errorf("Pluck() on nil slice should return nil, got %v", resultNil)
+		}
+		empty := []User{}
+		resultEmpty := Pluck(empty, func(item User) int { return item.ID })
+		if resultEmpty == nil || len(resultEmpty) != 0 {
+			t.Errorf("Pluck() on empty slice should return empty (len 0) non-nil slice, got %v", resultEmpty)
+		}
+	})
+}
+
+func TestChunk(t *testing.T) {
+	t.Run("chunks a slice into equal parts", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6}
+		expected := [][]int{{1, 2}, {3, 4}, {5, 6}}
+		result := Chunk(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Chunk() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("handles uneven chunks", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := [][]int{{1, 2}, {3, 4}, {5}}
+		result := Chunk(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Chunk() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []int{}
+		expected := [][]int{}
+		result := Chunk(input, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Chunk() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := Chunk(input, 2)
+		if result != nil {
+			t.Errorf("Chunk() on nil slice should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for invalid size", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		result := Chunk(input, 0)
+		if result != nil {
+			t.Errorf("Chunk() with size 0 should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestBatchRanges(t *testing.T) {
+	t.Run("splits a length into batch ranges", func(t *testing.T) {
+		expected := [][2]int{{0, 2}, {2, 4}, {4, 5}}
+		result := BatchRanges(5, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("BatchRanges() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("matches Chunk boundaries", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7}
+		chunks := Chunk(input, 3)
+		ranges := BatchRanges(len(input), 3)
+		if len(ranges) != len(chunks) {
+			t.Fatalf("BatchRanges() got %d ranges, want %d", len(ranges), len(chunks))
+		}
+		for i, r := range ranges {
+			if !reflect.DeepEqual(input[r[0]:r[1]], chunks[i]) {
+				t.Errorf("BatchRanges() range %v got = %v, want %v", r, input[r[0]:r[1]], chunks[i])
+			}
+		}
+	})
+
+	t.Run("returns empty slice for zero length", func(t *testing.T) {
+		result := BatchRanges(0, 2)
+		if result == nil || len(result) != 0 {
+			t.Errorf("BatchRanges() with zero length should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for size less than 1", func(t *testing.T) {
+		if result := BatchRanges(5, 0); result != nil {
+			t.Errorf("BatchRanges() with size 0 should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestChunkBySize(t *testing.T) {
+	byLen := func(item string) int { return len(item) }
+
+	t.Run("batches by cumulative size", func(t *testing.T) {
+		input := []string{"aa", "bbb", "c", "dddd", "ee"}
+		expected := [][]string{{"aa", "bbb"}, {"c", "dddd"}, {"ee"}}
+		result := ChunkBySize(input, 5, byLen)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ChunkBySize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("places oversized elements alone in their own chunk", func(t *testing.T) {
+		input := []string{"a", "bbbbbbbb", "c", "d", "eeeeeeeeee"}
+		expected := [][]string{{"a"}, {"bbbbbbbb"}, {"c", "d"}, {"eeeeeeeeee"}}
+		result := ChunkBySize(input, 3, byLen)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ChunkBySize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := []string{}
+		expected := [][]string{}
+		result := ChunkBySize(input, 3, byLen)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ChunkBySize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for invalid maxBytes", func(t *testing.T) {
+		input := []string{"a"}
+		result := ChunkBySize(input, 0, byLen)
+		if result != nil {
+			t.Errorf("ChunkBySize() with maxBytes 0 should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []string
+		result := ChunkBySize(input, 3, byLen)
+		if result != nil {
+			t.Errorf("ChunkBySize() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestBinPack(t *testing.T) {
+	identity := func(item int) int { return item }
+
+	t.Run("packs into the optimal number of bins for a known input", func(t *testing.T) {
+		input := []int{4, 8, 1, 4, 2, 1}
+		expected := [][]int{{8, 2}, {4, 4, 1, 1}}
+		result := BinPack(input, 10, identity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("BinPack() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("orders extreme weights without overflow", func(t *testing.T) {
+		input := []int{math.MinInt + 1, math.MaxInt}
+		expected := [][]int{{math.MaxInt, math.MinInt + 1}}
+		result := BinPack(input, 10, identity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("BinPack() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("places every item exactly once within capacity", func(t *testing.T) {
+		input := []int{5, 7, 5, 2, 4, 2, 5, 1, 6}
+		result := BinPack(input, 10, identity)
+		if len(result) != 4 {
+			t.Errorf("BinPack() used %d bins, want 4 (total weight 37)", len(result))
+		}
+		var placed []int
+		for _, bin := range result {
+			total := 0
+			for _, v := range bin {
+				total += v
+			}
+			if total > 10 {
+				t.Errorf("BinPack() bin %v exceeds capacity", bin)
+			}
+			placed = append(placed, bin...)
+		}
+		sortedInput := slices.Sorted(slices.Values(input))
+		slices.Sort(placed)
+		if !reflect.DeepEqual(placed, sortedInput) {
+			t.Errorf("BinPack() placed %v, want every item of %v exactly once", placed, input)
+		}
+	})
+
+	t.Run("places oversized items in their own bin", func(t *testing.T) {
+		input := []int{3, 15, 4}
+		expected := [][]int{{15}, {4, 3}}
+		result := BinPack(input, 10, identity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("BinPack() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		expected := [][]int{}
+		result := BinPack([]int{}, 10, identity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("BinPack() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input or invalid capacity", func(t *testing.T) {
+		var input []int
+		if result := BinPack(input, 10, identity); result != nil {
+			t.Errorf("BinPack() on nil slice should return nil, but got %v", result)
+		}
+		if result := BinPack([]int{1}, 0, identity); result != nil {
+			t.Errorf("BinPack() with capacity 0 should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	t.Run("flattens a slice of slices", func(t *testing.T) {
+		input := [][]int{{1, 2}, {3, 4}, {5, 6}}
+		expected := []int{1, 2, 3, 4, 5, 6}
+		result := Flatten(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Flatten() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("handles empty inner slices", func(t *testing.T) {
+		input := [][]int{{1, 2}, {}, {5, 6}}
+		expected := []int{1, 2, 5, 6}
+		result := Flatten(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Flatten() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		input := [][]int{}
+		expected := []int{}
+		result := Flatten(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Flatten() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input [][]int
+		result := Flatten(input)
+		if result != nil {
+			t.Errorf("Flatten() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestFlattenLimit(t *testing.T) {
+	input := [][]int{{1, 2}, {3, 4, 5}, {6}}
+
+	t.Run("stops mid-inner-slice at the limit", func(t *testing.T) {
+		expected := []int{1, 2, 3}
+		result := FlattenLimit(input, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenLimit() got = %v, want %v", result, expected)
+		}
+		if cap(result) != 3 {
+			t.Errorf("FlattenLimit() capacity got = %d, want 3", cap(result))
+		}
+	})
+
+	t.Run("returns everything when the limit is not reached", func(t *testing.T) {
+		expected := Flatten(input)
+		result := FlattenLimit(input, 100)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenLimit() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("does not share memory with the input", func(t *testing.T) {
+		result := FlattenLimit(input, 1)
+		result[0] = 99
+		if input[0][0] != 1 {
+			t.Errorf("FlattenLimit() should copy elements, but the input was modified to %v", input)
+		}
+	})
+
+	t.Run("returns empty slice for a non-positive limit", func(t *testing.T) {
+		for _, limit := range []int{0, -1} {
+			result := FlattenLimit(input, limit)
+			if result == nil || len(result) != 0 {
+				t.Errorf("FlattenLimit(limit=%d) should return empty slice, but got %v", limit, result)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := FlattenLimit[int](nil, 3); result != nil {
+			t.Errorf("FlattenLimit() on nil input should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestFlattenSeparated(t *testing.T) {
+	t.Run("inserts separator between groups", func(t *testing.T) {
+		expected := []int{1, 2, 0, 3, 0, 4, 5}
+		result := FlattenSeparated([][]int{{1, 2}, {3}, {4, 5}}, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenSeparated() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("adds no separator for a single group", func(t *testing.T) {
+		expected := []string{"a", "b"}
+		result := FlattenSeparated([][]string{{"a", "b"}}, "|")
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenSeparated() got = %v, want %v", result, expected)
 		}
 	})
 
-	t.Run("plucks an int property from a struct slice", func(t *testing.T) {
-		input := []User{
-			{ID: 1, Name: "Alice"},
-			{ID: 2, Name: "Bob"},
-			{ID: 3, Name: "Charlie"},
-		}
-		expected := []int{1, 2, 3}
-		result := Pluck(input, func(item User) int {
-			return item.ID
-		})
+	t.Run("keeps separators around empty groups", func(t *testing.T) {
+		expected := []int{1, 0, 0, 2}
+		result := FlattenSeparated([][]int{{1}, {}, {2}}, 0)
 		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Pluck() for ID got = %v, want %v", result, expected)
+			t.Errorf("FlattenSeparated() got = %v, want %v", result, expected)
 		}
 	})
 
 	t.Run("returns empty slice for empty input", func(t *testing.T) {
-		var input []User // actually nil leads to nil return; test both cases
-		resultNil := Pluck(input, func(item User) int { return item.ID })
-		if resultNil != nil {
-			t.Errorf("Pluck() on nil slice should return nil, got %v", resultNil)
+		result := FlattenSeparated([][]int{}, 0)
+		if result == nil || len(result) != 0 {
+			t.Errorf("FlattenSeparated() on empty input should return empty slice, but got %v", result)
 		}
-		empty := []User{}
-		resultEmpty := Pluck(empty, func(item User) int { return item.ID })
-		if resultEmpty == nil || len(resultEmpty) != 0 {
-			t.Errorf("Pluck() on empty slice should return empty (len 0) non-nil slice, got %v", resultEmpty)
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := FlattenSeparated[int](nil, 0); result != nil {
+			t.Errorf("FlattenSeparated() on nil input should return nil, but got %v", result)
 		}
 	})
 }
 
-func TestChunk(t *testing.T) {
-	t.Run("chunks a slice into equal parts", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5, 6}
-		expected := [][]int{{1, 2}, {3, 4}, {5, 6}}
-		result := Chunk(input, 2)
+func TestFlattenUnique(t *testing.T) {
+	t.Run("dedupes overlapping groups in first-appearance order", func(t *testing.T) {
+		expected := []int{3, 1, 2, 5, 4}
+		result := FlattenUnique([][]int{{3, 1, 3}, {2, 1, 5}, {5, 4, 3}})
 		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Chunk() got = %v, want %v", result, expected)
+			t.Errorf("FlattenUnique() got = %v, want %v", result, expected)
 		}
 	})
 
-	t.Run("handles uneven chunks", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
-		expected := [][]int{{1, 2}, {3, 4}, {5}}
-		result := Chunk(input, 2)
+	t.Run("matches Unique of Flatten", func(t *testing.T) {
+		input := [][]string{{"a", "b"}, {}, {"b", "c", "a"}}
+		expected := Unique(Flatten(input))
+		result := FlattenUnique(input)
 		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Chunk() got = %v, want %v", result, expected)
+			t.Errorf("FlattenUnique() got = %v, want %v", result, expected)
 		}
 	})
 
 	t.Run("returns empty slice for empty input", func(t *testing.T) {
-		input := []int{}
-		expected := [][]int{}
-		result := Chunk(input, 2)
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Chunk() got = %v, want %v", result, expected)
+		result := FlattenUnique([][]int{})
+		if result == nil || len(result) != 0 {
+			t.Errorf("FlattenUnique() on empty input should return empty slice, but got %v", result)
 		}
 	})
 
 	t.Run("returns nil for nil input", func(t *testing.T) {
-		var input []int
-		result := Chunk(input, 2)
-		if result != nil {
-			t.Errorf("Chunk() on nil slice should return nil, but got %v", result)
+		if result := FlattenUnique[int](nil); result != nil {
+			t.Errorf("FlattenUnique() on nil input should return nil, but got %v", result)
 		}
 	})
+}
 
-	t.Run("returns nil for invalid size", func(t *testing.T) {
-		input := []int{1, 2, 3}
-		result := Chunk(input, 0)
-		if result != nil {
-			t.Errorf("Chunk() with size 0 should return nil, but got %v", result)
+func TestFlattenGroupBy(t *testing.T) {
+	type item struct {
+		category string
+		id       int
+	}
+	byCategory := func(i item) string { return i.category }
+
+	t.Run("groups elements across nested slices by a field", func(t *testing.T) {
+		input := [][]item{
+			{{"fruit", 1}, {"veg", 2}},
+			{},
+			{{"fruit", 3}, {"dairy", 4}, {"veg", 5}},
+		}
+		expected := map[string][]item{
+			"fruit": {{"fruit", 1}, {"fruit", 3}},
+			"veg":   {{"veg", 2}, {"veg", 5}},
+			"dairy": {{"dairy", 4}},
+		}
+		result := FlattenGroupBy(input, byCategory)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenGroupBy() got = %v, want %v", result, expected)
+		}
+		if want := GroupBy(Flatten(input), byCategory); !reflect.DeepEqual(result, want) {
+			t.Errorf("FlattenGroupBy() got = %v, want GroupBy(Flatten()) = %v", result, want)
 		}
 	})
-}
 
-func TestFlatten(t *testing.T) {
-	t.Run("flattens a slice of slices", func(t *testing.T) {
-		input := [][]int{{1, 2}, {3, 4}, {5, 6}}
-		expected := []int{1, 2, 3, 4, 5, 6}
-		result := Flatten(input)
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Flatten() got = %v, want %v", result, expected)
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		result := FlattenGroupBy([][]item{}, byCategory)
+		if result == nil || len(result) != 0 {
+			t.Errorf("FlattenGroupBy() on empty input should return empty map, but got %v", result)
 		}
 	})
 
-	t.Run("handles empty inner slices", func(t *testing.T) {
-		input := [][]int{{1, 2}, {}, {5, 6}}
-		expected := []int{1, 2, 5, 6}
-		result := Flatten(input)
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Flatten() got = %v, want %v", result, expected)
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := FlattenGroupBy(nil, byCategory); result != nil {
+			t.Errorf("FlattenGroupBy() on nil input should return nil, but got %v", result)
 		}
 	})
+}
 
-	t.Run("returns empty slice for empty input", func(t *testing.T) {
-		input := [][]int{}
-		expected := []int{}
-		result := Flatten(input)
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Flatten() got = %v, want %v", result, expected)
+func TestFlattenIndexed(t *testing.T) {
+	t.Run("reports the originating group of each element", func(t *testing.T) {
+		input := [][]string{{"a", "b"}, {"c"}}
+		expectedFlat := []string{"a", "b", "c"}
+		expectedGroups := []int{0, 0, 1}
+		flat, groups := FlattenIndexed(input)
+		if !reflect.DeepEqual(flat, expectedFlat) {
+			t.Errorf("FlattenIndexed() flat got = %v, want %v", flat, expectedFlat)
+		}
+		if !reflect.DeepEqual(groups, expectedGroups) {
+			t.Errorf("FlattenIndexed() groupIndex got = %v, want %v", groups, expectedGroups)
+		}
+	})
+
+	t.Run("skips empty inner slices", func(t *testing.T) {
+		input := [][]int{{1}, {}, {2, 3}}
+		expectedFlat := []int{1, 2, 3}
+		expectedGroups := []int{0, 2, 2}
+		flat, groups := FlattenIndexed(input)
+		if !reflect.DeepEqual(flat, expectedFlat) {
+			t.Errorf("FlattenIndexed() flat got = %v, want %v", flat, expectedFlat)
+		}
+		if !reflect.DeepEqual(groups, expectedGroups) {
+			t.Errorf("FlattenIndexed() groupIndex got = %v, want %v", groups, expectedGroups)
 		}
 	})
 
 	t.Run("returns nil for nil input", func(t *testing.T) {
 		var input [][]int
-		result := Flatten(input)
+		flat, groups := FlattenIndexed(input)
+		if flat != nil || groups != nil {
+			t.Errorf("FlattenIndexed() on nil input should return (nil, nil), but got (%v, %v)", flat, groups)
+		}
+	})
+}
+
+func TestUnflatten(t *testing.T) {
+	t.Run("reconstructs groups from sizes", func(t *testing.T) {
+		input := [][]string{{"a", "b"}, {}, {"c"}, {"d", "e", "f"}}
+		flat := Flatten(input)
+		result, err := Unflatten(flat, []int{2, 0, 1, 3})
+		if err != nil {
+			t.Fatalf("Unflatten() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("Unflatten() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("returns error when sizes do not sum to length", func(t *testing.T) {
+		result, err := Unflatten([]int{1, 2, 3}, []int{1, 1})
+		if !errors.Is(err, ErrSizeMismatch) {
+			t.Errorf("Unflatten() error got = %v, want %v", err, ErrSizeMismatch)
+		}
 		if result != nil {
-			t.Errorf("Flatten() on nil slice should return nil, but got %v", result)
+			t.Errorf("Unflatten() on error should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns error for negative sizes", func(t *testing.T) {
+		_, err := Unflatten([]int{1, 2}, []int{3, -1})
+		if !errors.Is(err, ErrSizeMismatch) {
+			t.Errorf("Unflatten() error got = %v, want %v", err, ErrSizeMismatch)
+		}
+	})
+
+	t.Run("returns nil for nil inputs", func(t *testing.T) {
+		result, err := Unflatten[int](nil, nil)
+		if result != nil || err != nil {
+			t.Errorf("Unflatten() on nil inputs got = (%v, %v), want (nil, nil)", result, err)
+		}
+	})
+
+	t.Run("returns error instead of panicking when sizes overflow", func(t *testing.T) {
+		result, err := Unflatten([]int{}, []int{math.MaxInt, math.MaxInt, 2})
+		if !errors.Is(err, ErrSizeMismatch) {
+			t.Errorf("Unflatten() error got = %v, want %v", err, ErrSizeMismatch)
+		}
+		if result != nil {
+			t.Errorf("Unflatten() on error should return nil, but got %v", result)
 		}
 	})
 }
@@ -278,6 +1158,249 @@ func TestGroupBy(t *testing.T) {
 	})
 }
 
+func TestGroupByOrdered(t *testing.T) {
+	firstLetter := func(item string) string { return item[:1] }
+
+	t.Run("returns keys in first-appearance order", func(t *testing.T) {
+		input := []string{"cherry", "apple", "banana", "avocado", "blueberry", "cranberry"}
+		expectedGroups := map[string][]string{
+			"c": {"cherry", "cranberry"},
+			"a": {"apple", "avocado"},
+			"b": {"banana", "blueberry"},
+		}
+		expectedOrder := []string{"c", "a", "b"}
+		groups, keyOrder := GroupByOrdered(input, firstLetter)
+		if !reflect.DeepEqual(groups, expectedGroups) {
+			t.Errorf("GroupByOrdered() groups got = %v, want %v", groups, expectedGroups)
+		}
+		if !reflect.DeepEqual(keyOrder, expectedOrder) {
+			t.Errorf("GroupByOrdered() keyOrder got = %v, want %v", keyOrder, expectedOrder)
+		}
+	})
+
+	t.Run("returns empty results for empty input", func(t *testing.T) {
+		groups, keyOrder := GroupByOrdered([]string{}, firstLetter)
+		if groups == nil || len(groups) != 0 || keyOrder == nil || len(keyOrder) != 0 {
+			t.Errorf("GroupByOrdered() on empty slice should return empty non-nil results, got (%v, %v)", groups, keyOrder)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []string
+		groups, keyOrder := GroupByOrdered(input, firstLetter)
+		if groups != nil || keyOrder != nil {
+			t.Errorf("GroupByOrdered() on nil slice should return (nil, nil), got (%v, %v)", groups, keyOrder)
+		}
+	})
+}
+
+func TestGroupBySorted(t *testing.T) {
+	type event struct {
+		day  string
+		hour int
+	}
+	byDay := func(item event) string { return item.day }
+	byHour := func(a, b event) bool { return a.hour < b.hour }
+
+	t.Run("sorts each group independently", func(t *testing.T) {
+		input := []event{{"mon", 9}, {"tue", 14}, {"mon", 7}, {"tue", 8}, {"mon", 12}}
+		original := slices.Clone(input)
+		expected := map[string][]event{
+			"mon": {{"mon", 7}, {"mon", 9}, {"mon", 12}},
+			"tue": {{"tue", 8}, {"tue", 14}},
+		}
+		result := GroupBySorted(input, byDay, byHour)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupBySorted() got = %v, want %v", result, expected)
+		}
+		if !reflect.DeepEqual(input, original) {
+			t.Errorf("GroupBySorted() modified the input: got %v, want %v", input, original)
+		}
+	})
+
+	t.Run("keeps equal elements in input order", func(t *testing.T) {
+		input := []event{{"mon", 9}, {"mon", 4}, {"mon", 5}, {"mon", 2}}
+		byParity := func(a, b event) bool { return a.hour%2 < b.hour%2 }
+		expected := map[string][]event{"mon": {{"mon", 4}, {"mon", 2}, {"mon", 9}, {"mon", 5}}}
+		result := GroupBySorted(input, byDay, byParity)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupBySorted() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		result := GroupBySorted([]event{}, byDay, byHour)
+		if result == nil || len(result) != 0 {
+			t.Errorf("GroupBySorted() on empty slice should return empty map, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := GroupBySorted([]event(nil), byDay, byHour); result != nil {
+			t.Errorf("GroupBySorted() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestGroupByCapped(t *testing.T) {
+	firstLetter := func(item string) byte { return item[0] }
+
+	t.Run("sends elements beyond the cap to overflow in order", func(t *testing.T) {
+		input := []string{"apple", "avocado", "banana", "apricot", "blueberry", "almond", "cherry"}
+		groups, overflow := GroupByCapped(input, firstLetter, 2)
+		expectedGroups := map[byte][]string{
+			'a': {"apple", "avocado"},
+			'b': {"banana", "blueberry"},
+			'c': {"cherry"},
+		}
+		if !reflect.DeepEqual(groups, expectedGroups) {
+			t.Errorf("GroupByCapped() groups got = %v, want %v", groups, expectedGroups)
+		}
+		if expected := []string{"apricot", "almond"}; !reflect.DeepEqual(overflow, expected) {
+			t.Errorf("GroupByCapped() overflow got = %v, want %v", overflow, expected)
+		}
+	})
+
+	t.Run("puts everything in overflow when the cap is not positive", func(t *testing.T) {
+		input := []string{"apple", "banana"}
+		groups, overflow := GroupByCapped(input, firstLetter, 0)
+		if groups == nil || len(groups) != 0 {
+			t.Errorf("GroupByCapped() groups should be empty map, but got %v", groups)
+		}
+		if !reflect.DeepEqual(overflow, input) {
+			t.Errorf("GroupByCapped() overflow got = %v, want %v", overflow, input)
+		}
+	})
+
+	t.Run("returns empty overflow when no group exceeds the cap", func(t *testing.T) {
+		_, overflow := GroupByCapped([]string{"apple", "banana"}, firstLetter, 1)
+		if overflow == nil || len(overflow) != 0 {
+			t.Errorf("GroupByCapped() overflow should be empty slice, but got %v", overflow)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		groups, overflow := GroupByCapped([]string(nil), firstLetter, 2)
+		if groups != nil || overflow != nil {
+			t.Errorf("GroupByCapped() on nil slice got = (%v, %v), want (nil, nil)", groups, overflow)
+		}
+	})
+}
+
+func TestGroupByReduce(t *testing.T) {
+	type Sale struct {
+		Region string
+		Amount int
+	}
+	byRegion := func(item Sale) string { return item.Region }
+	sumAmount := func(acc int, item Sale) int { return acc + item.Amount }
+
+	t.Run("sums a field grouped by category", func(t *testing.T) {
+		input := []Sale{
+			{Region: "north", Amount: 10},
+			{Region: "south", Amount: 5},
+			{Region: "north", Amount: 7},
+			{Region: "east", Amount: 1},
+		}
+		expected := map[string]int{"north": 17, "south": 5, "east": 1}
+		result := GroupByReduce(input, byRegion, 0, sumAmount)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupByReduce() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("starts each group from the initial value", func(t *testing.T) {
+		input := []Sale{{Region: "north", Amount: 1}, {Region: "south", Amount: 2}}
+		expected := map[string]int{"north": 101, "south": 102}
+		result := GroupByReduce(input, byRegion, 100, sumAmount)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("GroupByReduce() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		input := []Sale{}
+		result := GroupByReduce(input, byRegion, 0, sumAmount)
+		if result == nil || len(result) != 0 {
+			t.Errorf("GroupByReduce() on empty slice should return empty non-nil map, got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []Sale
+		result := GroupByReduce(input, byRegion, 0, sumAmount)
+		if result != nil {
+			t.Errorf("GroupByReduce() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestTransformGroups(t *testing.T) {
+	length := func(group []string) int { return len(group) }
+
+	t.Run("turns grouped slices into their lengths", func(t *testing.T) {
+		groups := GroupBy([]string{"apple", "avocado", "banana", "cherry", "apricot"}, func(item string) byte {
+			return item[0]
+		})
+		expected := map[byte]int{'a': 3, 'b': 1, 'c': 1}
+		result := TransformGroups(groups, length)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TransformGroups() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty map for empty input", func(t *testing.T) {
+		result := TransformGroups(map[string][]string{}, length)
+		if result == nil || len(result) != 0 {
+			t.Errorf("TransformGroups() on empty map should return empty non-nil map, got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var groups map[string][]string
+		result := TransformGroups(groups, length)
+		if result != nil {
+			t.Errorf("TransformGroups() on nil map should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSessionize(t *testing.T) {
+	gapOver := func(limit int) func(prev, cur int) bool {
+		return func(prev, cur int) bool { return cur-prev > limit }
+	}
+
+	t.Run("starts new sessions when the gap predicate triggers", func(t *testing.T) {
+		timestamps := []int{0, 5, 40, 42, 100}
+		expected := []int{0, 0, 1, 1, 2}
+		result := Sessionize(timestamps, gapOver(30))
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Sessionize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("labels everything 0 when no gap triggers", func(t *testing.T) {
+		expected := []int{0, 0, 0}
+		result := Sessionize([]int{1, 2, 3}, gapOver(30))
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Sessionize() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		result := Sessionize([]int{}, gapOver(30))
+		if result == nil || len(result) != 0 {
+			t.Errorf("Sessionize() on empty slice should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := Sessionize([]int(nil), gapOver(30)); result != nil {
+			t.Errorf("Sessionize() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
 func TestReduce(t *testing.T) {
 	t.Run("sums integers", func(t *testing.T) {
 		input := []int{1, 2, 3, 4, 5}
@@ -335,6 +1458,45 @@ func TestReduce(t *testing.T) {
 	})
 }
 
+func TestFoldMap(t *testing.T) {
+	runningSum := func(sum int, item int, _ int) (int, int) { return sum + item, item * 2 }
+
+	t.Run("returns the final state and emitted outputs", func(t *testing.T) {
+		state, outputs := FoldMap([]int{1, 2, 3, 4}, 10, runningSum)
+		if state != 20 {
+			t.Errorf("FoldMap() state got = %v, want 20", state)
+		}
+		if expected := []int{2, 4, 6, 8}; !reflect.DeepEqual(outputs, expected) {
+			t.Errorf("FoldMap() outputs got = %v, want %v", outputs, expected)
+		}
+	})
+
+	t.Run("passes the updated state and index to each step", func(t *testing.T) {
+		labels := func(seen string, item string, index int) (string, string) {
+			return seen + item, seen + ":" + strconv.Itoa(index)
+		}
+		state, outputs := FoldMap([]string{"a", "b", "c"}, "", labels)
+		expected := []string{":0", "a:1", "ab:2"}
+		if state != "abc" || !reflect.DeepEqual(outputs, expected) {
+			t.Errorf("FoldMap() got = (%q, %v), want (%q, %v)", state, outputs, "abc", expected)
+		}
+	})
+
+	t.Run("returns initial state and empty slice for empty input", func(t *testing.T) {
+		state, outputs := FoldMap([]int{}, 7, runningSum)
+		if state != 7 || outputs == nil || len(outputs) != 0 {
+			t.Errorf("FoldMap() on empty slice got = (%v, %v), want (7, [])", state, outputs)
+		}
+	})
+
+	t.Run("returns initial state and nil for nil input", func(t *testing.T) {
+		state, outputs := FoldMap([]int(nil), 7, runningSum)
+		if state != 7 || outputs != nil {
+			t.Errorf("FoldMap() on nil slice got = (%v, %v), want (7, nil)", state, outputs)
+		}
+	})
+}
+
 func TestIntersect(t *testing.T) {
 	t.Run("finds common elements", func(t *testing.T) {
 		slice1 := []int{1, 2, 3, 4}
@@ -393,3 +1555,52 @@ func TestIntersect(t *testing.T) {
 		}
 	})
 }
+
+func TestIntersectMultiset(t *testing.T) {
+	t.Run("keeps the minimum multiplicity across slices", func(t *testing.T) {
+		slice1 := []int{1, 1, 2}
+		slice2 := []int{1, 2, 2}
+		expected := []int{1, 2}
+		result := IntersectMultiset(slice1, slice2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IntersectMultiset() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("preserves duplicates shared by all slices", func(t *testing.T) {
+		slice1 := []int{3, 1, 3, 1, 3}
+		slice2 := []int{1, 3, 3, 1}
+		slice3 := []int{3, 1, 3, 3, 1, 1}
+		expected := []int{3, 1, 3, 1}
+		result := IntersectMultiset(slice1, slice2, slice3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IntersectMultiset() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice when no common elements", func(t *testing.T) {
+		slice1 := []int{1, 2}
+		slice2 := []int{3, 4}
+		expected := []int{}
+		result := IntersectMultiset(slice1, slice2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IntersectMultiset() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns a copy of the only slice", func(t *testing.T) {
+		slice1 := []int{1, 1, 2}
+		expected := []int{1, 1, 2}
+		result := IntersectMultiset(slice1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IntersectMultiset() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil when no slices provided", func(t *testing.T) {
+		result := IntersectMultiset[[]int]()
+		if result != nil {
+			t.Errorf("IntersectMultiset() with no slices should return nil, but got %v", result)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ErrCycle is returned when a dependency graph contains a cycle.
+var ErrCycle = errors.New("util: dependency cycle detected")
+
+// SortedInsert returns a new slice with value inserted at its sorted position, assuming
+// collection is already sorted in ascending order. The position is found by binary search
+// and value is placed after any elements equal to it. The input is never modified.
+func SortedInsert[E cmp.Ordered](collection []E, value E) []E {
+	return SortedInsertBy(collection, value, cmp.Compare[E])
+}
+
+// SortedInsertBy is like SortedInsert but orders elements with the given comparator,
+// which must return a negative number when a < b, zero when a == b and a positive
+// number when a > b. collection must already be sorted according to compare.
+func SortedInsertBy[E any](collection []E, value E, compare func(a, b E) int) []E {
+	position := sort.Search(len(collection), func(i int) bool {
+		return compare(collection[i], value) > 0
+	})
+
+	result := make([]E, 0, len(collection)+1)
+	result = append(result, collection[:position]...)
+	result = append(result, value)
+	result = append(result, collection[position:]...)
+	return result
+}
+
+// MergeSorted merges two slices that are each sorted in ascending order into a new sorted
+// slice in O(n+m) time. Duplicates are preserved, and when elements compare equal those
+// from a come first. A nil input is treated as empty; if both inputs are nil it returns nil.
+func MergeSorted[E cmp.Ordered](a, b []E) []E {
+	return MergeSortedBy(a, b, cmp.Compare[E])
+}
+
+// MergeSortedBy is like MergeSorted but orders elements with the given comparator.
+// Both inputs must already be sorted according to compare.
+func MergeSortedBy[E any](a, b []E, compare func(x, y E) int) []E {
+	if a == nil && b == nil {
+		return nil
+	}
+
+	result := make([]E, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if compare(b[j], a[i]) < 0 {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+		}
+	}
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+	return result
+}
+
+// MergeSortedUnique merges two slices that are each sorted in ascending order into a new
+// sorted slice containing every distinct value exactly once, dropping duplicates both
+// within and across the inputs. A nil input is treated as empty; if both inputs are nil
+// it returns nil.
+func MergeSortedUnique[E cmp.Ordered](a, b []E) []E {
+	merged := MergeSorted(a, b)
+	if merged == nil {
+		return nil
+	}
+
+	result := merged[:0]
+	for i, item := range merged {
+		if i == 0 || item != result[len(result)-1] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SortByKey returns a new slice sorted in ascending order by the key that keySelector
+// extracts from each element, which avoids writing a full comparator for the common case
+// of sorting by one field. The sort is stable, and keySelector is called once per element.
+// The input is not modified. It returns nil for nil input.
+func SortByKey[S ~[]E, E any, K cmp.Ordered](collection S, keySelector func(item E) K) S {
+	return sortByKey(collection, keySelector, cmp.Compare[K])
+}
+
+// SortByKeyDesc is like SortByKey but sorts in descending order of the key. Elements with
+// equal keys keep their original relative order.
+func SortByKeyDesc[S ~[]E, E any, K cmp.Ordered](collection S, keySelector func(item E) K) S {
+	return sortByKey(collection, keySelector, func(a, b K) int { return cmp.Compare(b, a) })
+}
+
+// sortByKey implements SortByKey and SortByKeyDesc.
+func sortByKey[S ~[]E, E any, K cmp.Ordered](collection S, keySelector func(item E) K, compare func(a, b K) int) S {
+	if collection == nil {
+		return nil
+	}
+
+	keyed := make([]Pair[K, E], len(collection))
+	for i, item := range collection {
+		keyed[i] = Pair[K, E]{First: keySelector(item), Second: item}
+	}
+	slices.SortStableFunc(keyed, func(a, b Pair[K, E]) int { return compare(a.First, b.First) })
+
+	result := make(S, len(keyed))
+	for i, entry := range keyed {
+		result[i] = entry.Second
+	}
+	return result
+}
+
+// SortedEntries returns the entries of a map as key/value pairs sorted in ascending order
+// by key. Unlike ranging over the map, the output is deterministic, which makes it suitable
+// for stable serialization. It returns nil for a nil map and an empty (non-nil) slice for
+// an empty map.
+func SortedEntries[K cmp.Ordered, V any](m map[K]V) []Pair[K, V] {
+	if m == nil {
+		return nil
+	}
+
+	result := make([]Pair[K, V], 0, len(m))
+	for key, value := range m {
+		result = append(result, Pair[K, V]{First: key, Second: value})
+	}
+	slices.SortFunc(result, func(a, b Pair[K, V]) int {
+		return cmp.Compare(a.First, b.First)
+	})
+	return result
+}
+
+// FlattenGroups concatenates the groups of a grouped map, such as the result of GroupBy,
+// into a single slice in ascending key order. Elements keep their order within each group,
+// so for ordered keys this is a deterministic inverse of GroupBy. It returns nil for a nil
+// map and an empty (non-nil) slice for an empty map.
+func FlattenGroups[K cmp.Ordered, S ~[]E, E any](groups map[K]S) []E {
+	if groups == nil {
+		return nil
+	}
+
+	total := 0
+	for _, group := range groups {
+		total += len(group)
+	}
+
+	result := make([]E, 0, total)
+	for _, entry := range SortedEntries(groups) {
+		result = append(result, entry.Second...)
+	}
+	return result
+}
+
+// TopoSort returns items ordered so that every item comes after all of its dependencies,
+// as reported by deps. Among independent items, the order of items is preserved, so the
+// result is deterministic. Duplicate items appear once.
+//
+// Dependencies that are not present in items are ignored, which lets callers sort a subset
+// of a larger graph. If the graph contains a cycle, it returns nil and an error wrapping
+// ErrCycle that names an item on the cycle. It returns (nil, nil) for nil input.
+func TopoSort[E comparable](items []E, deps func(item E) []E) ([]E, error) {
+	if items == nil {
+		return nil, nil
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[E]int, len(items))
+	for _, item := range items {
+		state[item] = unvisited
+	}
+
+	result := make([]E, 0, len(state))
+	var visit func(item E) error
+	visit = func(item E) error {
+		switch state[item] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: involving %v", ErrCycle, item)
+		}
+
+		state[item] = visiting
+		for _, dep := range deps(item) {
+			if _, known := state[dep]; !known {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[item] = visited
+		result = append(result, item)
+		return nil
+	}
+
+	for _, item := range items {
+		if err := visit(item); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,319 @@
+package util
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortedInsert(t *testing.T) {
+	t.Run("inserts at the beginning", func(t *testing.T) {
+		input := []int{2, 4, 6}
+		expected := []int{1, 2, 4, 6}
+		result := SortedInsert(input, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsert() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("inserts in the middle", func(t *testing.T) {
+		input := []int{2, 4, 6}
+		expected := []int{2, 4, 5, 6}
+		result := SortedInsert(input, 5)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsert() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("inserts at the end", func(t *testing.T) {
+		input := []int{2, 4, 6}
+		expected := []int{2, 4, 6, 7}
+		result := SortedInsert(input, 7)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsert() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("inserts into an empty slice", func(t *testing.T) {
+		expected := []int{3}
+		if result := SortedInsert([]int{}, 3); !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsert() got = %v, want %v", result, expected)
+		}
+		if result := SortedInsert(nil, 3); !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsert() on nil slice got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("does not modify the input", func(t *testing.T) {
+		input := make([]int, 3, 10)
+		copy(input, []int{1, 3, 5})
+		SortedInsert(input, 2)
+		if !reflect.DeepEqual(input, []int{1, 3, 5}) || !reflect.DeepEqual(input[:4], []int{1, 3, 5, 0}) {
+			t.Errorf("SortedInsert() modified input: got %v", input[:4])
+		}
+	})
+}
+
+func TestSortedInsertBy(t *testing.T) {
+	t.Run("inserts using a comparator", func(t *testing.T) {
+		input := []string{"kiwi", "apple", "banana"}
+		byLen := func(a, b string) int { return len(a) - len(b) }
+		expected := []string{"kiwi", "apple", "grape", "banana"}
+		result := SortedInsertBy(input, "grape", byLen)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsertBy() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("supports descending order", func(t *testing.T) {
+		input := []string{"c", "b", "a"}
+		descending := func(a, b string) int { return strings.Compare(b, a) }
+		expected := []string{"d", "c", "b", "a"}
+		result := SortedInsertBy(input, "d", descending)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedInsertBy() got = %v, want %v", result, expected)
+		}
+	})
+}
+
+func TestMergeSorted(t *testing.T) {
+	t.Run("interleaves two sorted slices preserving duplicates", func(t *testing.T) {
+		a := []int{1, 3, 5, 7}
+		b := []int{2, 3, 6}
+		expected := []int{1, 2, 3, 3, 5, 6, 7}
+		result := MergeSorted(a, b)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSorted() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns a copy when one input is empty", func(t *testing.T) {
+		a := []int{1, 2, 3}
+		expected := []int{1, 2, 3}
+		if result := MergeSorted(a, []int{}); !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSorted() got = %v, want %v", result, expected)
+		}
+		result := MergeSorted(nil, a)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSorted() got = %v, want %v", result, expected)
+		}
+		result[0] = 99
+		if a[0] == 99 {
+			t.Errorf("MergeSorted() should return a copy, not alias the input")
+		}
+	})
+
+	t.Run("returns nil when both inputs are nil", func(t *testing.T) {
+		result := MergeSorted[int](nil, nil)
+		if result != nil {
+			t.Errorf("MergeSorted() on nil slices should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMergeSortedBy(t *testing.T) {
+	t.Run("merges using a comparator and keeps a's elements first on ties", func(t *testing.T) {
+		a := []string{"b", "ccc"}
+		b := []string{"a", "dd", "eee"}
+		byLen := func(x, y string) int { return len(x) - len(y) }
+		expected := []string{"b", "a", "dd", "ccc", "eee"}
+		result := MergeSortedBy(a, b, byLen)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSortedBy() got = %v, want %v", result, expected)
+		}
+	})
+}
+
+func TestMergeSortedUnique(t *testing.T) {
+	t.Run("drops duplicates across and within inputs", func(t *testing.T) {
+		a := []int{1, 1, 3, 5}
+		b := []int{1, 3, 4, 5, 5}
+		expected := []int{1, 3, 4, 5}
+		result := MergeSortedUnique(a, b)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSortedUnique() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty inputs", func(t *testing.T) {
+		expected := []int{}
+		result := MergeSortedUnique([]int{}, nil)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MergeSortedUnique() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil when both inputs are nil", func(t *testing.T) {
+		result := MergeSortedUnique[int](nil, nil)
+		if result != nil {
+			t.Errorf("MergeSortedUnique() on nil slices should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSortByKey(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+	byName := func(item user) string { return item.name }
+	input := []user{{"carol", 35}, {"alice", 30}, {"bob", 25}, {"alice", 40}}
+
+	t.Run("sorts ascending by the selected key", func(t *testing.T) {
+		original := slices.Clone(input)
+		expected := []user{{"alice", 30}, {"alice", 40}, {"bob", 25}, {"carol", 35}}
+		result := SortByKey(input, byName)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortByKey() got = %v, want %v", result, expected)
+		}
+		if !reflect.DeepEqual(input, original) {
+			t.Errorf("SortByKey() modified the input: got %v, want %v", input, original)
+		}
+	})
+
+	t.Run("sorts descending by the selected key", func(t *testing.T) {
+		expected := []user{{"carol", 35}, {"bob", 25}, {"alice", 30}, {"alice", 40}}
+		result := SortByKeyDesc(input, byName)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortByKeyDesc() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("calls the selector once per element", func(t *testing.T) {
+		calls := 0
+		SortByKey(input, func(item user) int {
+			calls++
+			return item.age
+		})
+		if calls != len(input) {
+			t.Errorf("SortByKey() called the selector %d times, want %d", calls, len(input))
+		}
+	})
+
+	t.Run("returns empty slice for empty input and nil for nil input", func(t *testing.T) {
+		if result := SortByKey([]user{}, byName); result == nil || len(result) != 0 {
+			t.Errorf("SortByKey() on empty slice should return empty slice, but got %v", result)
+		}
+		if result := SortByKeyDesc([]user(nil), byName); result != nil {
+			t.Errorf("SortByKeyDesc() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSortedEntries(t *testing.T) {
+	t.Run("returns entries sorted by key", func(t *testing.T) {
+		input := map[string]int{"pear": 3, "apple": 1, "mango": 2, "banana": 4}
+		expected := []Pair[string, int]{{"apple", 1}, {"banana", 4}, {"mango", 2}, {"pear", 3}}
+		result := SortedEntries(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedEntries() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty map", func(t *testing.T) {
+		expected := []Pair[int, string]{}
+		result := SortedEntries(map[int]string{})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("SortedEntries() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil map", func(t *testing.T) {
+		var input map[int]string
+		result := SortedEntries(input)
+		if result != nil {
+			t.Errorf("SortedEntries() on nil map should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestFlattenGroups(t *testing.T) {
+	t.Run("concatenates groups in ascending key order", func(t *testing.T) {
+		groups := map[string][]int{
+			"c": {7, 8},
+			"a": {3, 1, 2},
+			"b": {},
+			"d": {9},
+		}
+		expected := []int{3, 1, 2, 7, 8, 9}
+		result := FlattenGroups(groups)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenGroups() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("inverts GroupBy for ordered keys", func(t *testing.T) {
+		input := []int{5, 2, 8, 3, 6, 9}
+		grouped := GroupBy(input, func(item int) int { return item % 3 })
+		expected := []int{3, 6, 9, 5, 2, 8}
+		result := FlattenGroups(grouped)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlattenGroups() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty map", func(t *testing.T) {
+		result := FlattenGroups(map[int][]string{})
+		if result == nil || len(result) != 0 {
+			t.Errorf("FlattenGroups() on empty map should return empty slice, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil map", func(t *testing.T) {
+		if result := FlattenGroups[int, []string](nil); result != nil {
+			t.Errorf("FlattenGroups() on nil map should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestTopoSort(t *testing.T) {
+	t.Run("orders a DAG so dependencies come first", func(t *testing.T) {
+		graph := map[string][]string{
+			"app":    {"db", "cache"},
+			"db":     {"config"},
+			"cache":  {"config"},
+			"config": {},
+		}
+		input := []string{"app", "db", "cache", "config"}
+		expected := []string{"config", "db", "cache", "app"}
+		result, err := TopoSort(input, func(item string) []string { return graph[item] })
+		if err != nil {
+			t.Fatalf("TopoSort() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TopoSort() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("ignores dependencies not present in items", func(t *testing.T) {
+		graph := map[int][]int{1: {2, 99}, 2: {}}
+		expected := []int{2, 1}
+		result, err := TopoSort([]int{1, 2}, func(item int) []int { return graph[item] })
+		if err != nil {
+			t.Fatalf("TopoSort() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TopoSort() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns an error for a cyclic graph", func(t *testing.T) {
+		graph := map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}}
+		result, err := TopoSort([]string{"a", "b", "c"}, func(item string) []string { return graph[item] })
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("TopoSort() error got = %v, want %v", err, ErrCycle)
+		}
+		if result != nil {
+			t.Errorf("TopoSort() on cycle should return nil result, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		result, err := TopoSort[int](nil, func(int) []int { return nil })
+		if result != nil || err != nil {
+			t.Errorf("TopoSort() on nil slice got = (%v, %v), want (nil, nil)", result, err)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "cmp"
+
+// WindowMap applies f to every sliding window of the given size and returns one
+// result per window, in order. Windows advance by one element, so a collection of
+// length n yields n-size+1 results.
+//
+// Each window is a capacity-limited view into collection rather than a copy, which
+// avoids materializing an intermediate [][]E; f must not retain or modify it.
+// It returns nil for nil input, or if size is less than 1 or greater than the length
+// of the collection.
+func WindowMap[S ~[]E, E any, R any](collection S, size int, f func(window S) R) []R {
+	if collection == nil || size < 1 || size > len(collection) {
+		return nil
+	}
+
+	count := len(collection) - size + 1
+	result := make([]R, count)
+	for i := range count {
+		result[i] = f(collection[i : i+size : i+size])
+	}
+	return result
+}
+
+// RollingMap generalizes WindowMap with a step: it applies f to windows of the given size
+// that start every step elements and returns one result per window, in order. Only full
+// windows are used; trailing elements that cannot fill a final window are ignored, so a
+// collection of length n yields (n-size)/step+1 results. With a step of 1 it matches
+// WindowMap, and with a step equal to size the windows tile the collection.
+//
+// Each window is a capacity-limited view into collection; f must not retain or modify it.
+// It returns nil for nil input, or if size or step is less than 1 or size is greater than
+// the length of the collection.
+func RollingMap[S ~[]E, E any, R any](collection S, size, step int, f func(window S) R) []R {
+	if collection == nil || size < 1 || step < 1 || size > len(collection) {
+		return nil
+	}
+
+	result := make([]R, 0, (len(collection)-size)/step+1)
+	for start := 0; start+size <= len(collection); start += step {
+		result = append(result, f(collection[start:start+size:start+size]))
+	}
+	return result
+}
+
+// MapPairwise applies f to every pair of adjacent elements and returns one result per
+// pair, in order, which suits computing deltas or ratios between consecutive values. The
+// index passed to f is the position of cur, so it runs from 1 to len(collection)-1.
+// It returns nil for nil input and an empty (non-nil) slice when there are fewer than two
+// elements.
+func MapPairwise[S ~[]E, E any, R any](collection S, f func(prev, cur E, index int) R) []R {
+	if collection == nil {
+		return nil
+	}
+	if len(collection) < 2 {
+		return []R{}
+	}
+
+	result := make([]R, len(collection)-1)
+	for i := 1; i < len(collection); i++ {
+		result[i-1] = f(collection[i-1], collection[i], i)
+	}
+	return result
+}
+
+// WindowClone returns every sliding window of the given size as an independent copy.
+// Windows advance by one element, so a collection of length n yields n-size+1 windows.
+//
+// Because consecutive windows overlap, views into the input would share elements: writing
+// to one window would silently change its neighbours and the input. WindowClone avoids this
+// by copying, at the cost of O((n-size+1)*size) memory instead of O(1) per window; prefer
+// WindowMap when each window is only read. It returns nil for nil input, or if size is
+// less than 1 or greater than the length of the collection.
+func WindowClone[S ~[]E, E any](collection S, size int) []S {
+	if collection == nil || size < 1 || size > len(collection) {
+		return nil
+	}
+
+	count := len(collection) - size + 1
+	// A single backing array keeps allocations low; full slice expressions keep each
+	// window's capacity to its own region so appends cannot spill into the next one.
+	backing := make(S, count*size)
+	result := make([]S, count)
+	for i := range count {
+		window := backing[i*size : (i+1)*size : (i+1)*size]
+		copy(window, collection[i:i+size])
+		result[i] = window
+	}
+	return result
+}
+
+// UnWindow reconstructs the original slice from overlapping windows that were taken every
+// step elements, by concatenating the first step elements of each window followed by the
+// whole of the last window. The result is a new slice; the windows are not modified.
+//
+// The reconstruction is only faithful when all windows have the same size, step is between
+// 1 and that size, and the last window ends at the end of the original slice, that is
+// (len(original)-size) is a multiple of step. It returns nil for nil input or if step is
+// less than 1.
+func UnWindow[S ~[]E, E any](windows []S, step int) S {
+	if windows == nil || step < 1 {
+		return nil
+	}
+	if len(windows) == 0 {
+		return S{}
+	}
+
+	last := windows[len(windows)-1]
+	result := make(S, 0, (len(windows)-1)*step+len(last))
+	for _, window := range windows[:len(windows)-1] {
+		result = append(result, window[:min(step, len(window))]...)
+	}
+	return append(result, last...)
+}
+
+// SlidingMax returns the maximum of every sliding window of the given size, in order.
+// It uses a monotonic deque so the total running time is O(n) regardless of size,
+// compared to O(n*size) for WindowMap with a naive max.
+// It returns nil for nil input, or if size is less than 1 or greater than the length
+// of the collection.
+func SlidingMax[E cmp.Ordered](collection []E, size int) []E {
+	return slidingExtreme(collection, size, func(a, b E) bool { return a >= b })
+}
+
+// SlidingMin returns the minimum of every sliding window of the given size, in order.
+// It uses a monotonic deque so the total running time is O(n) regardless of size,
+// compared to O(n*size) for WindowMap with a naive min.
+// It returns nil for nil input, or if size is less than 1 or greater than the length
+// of the collection.
+func SlidingMin[E cmp.Ordered](collection []E, size int) []E {
+	return slidingExtreme(collection, size, func(a, b E) bool { return a <= b })
+}
+
+// WindowStat holds aggregate statistics for one window, as returned by RollingStats.
+type WindowStat[E Number] struct {
+	Count int
+	Sum   E
+	Min   E
+	Max   E
+	Mean  float64
+}
+
+// RollingStats returns the count, sum, minimum, maximum and mean of every sliding window
+// of the given size, in order, so the statistics come from one call instead of one pass
+// per statistic. Windows advance by one element, so a collection of length n yields
+// n-size+1 results. Each window is summed directly rather than with a running total,
+// which keeps float sums exact per window and infinities from poisoning later windows,
+// at a cost of O(n*size) time. Sum is accumulated in E and may overflow for small integer
+// types. It returns nil for nil input, or if size is less than 1 or greater than the
+// length of the collection.
+func RollingStats[E Number](collection []E, size int) []WindowStat[E] {
+	return WindowMap(collection, size, func(window []E) WindowStat[E] {
+		stat := WindowStat[E]{Count: len(window), Min: window[0], Max: window[0]}
+		for _, item := range window {
+			stat.Sum += item
+			stat.Min = min(stat.Min, item)
+			stat.Max = max(stat.Max, item)
+		}
+		stat.Mean = float64(stat.Sum) / float64(stat.Count)
+		return stat
+	})
+}
+
+// slidingExtreme implements SlidingMax and SlidingMin. The deque holds indices whose
+// values are ordered so that dominates(front, back) holds; the front is always the
+// extreme of the current window.
+func slidingExtreme[E cmp.Ordered](collection []E, size int, dominates func(a, b E) bool) []E {
+	if collection == nil || size < 1 || size > len(collection) {
+		return nil
+	}
+
+	result := make([]E, 0, len(collection)-size+1)
+	deque := make([]int, 0, size)
+	for i, item := range collection {
+		// Drop the front index once it has slid out of the window
+		if len(deque) > 0 && deque[0] <= i-size {
+			deque = deque[1:]
+		}
+
+		// Drop indices from the back that can never be the extreme again
+		for len(deque) > 0 && !dominates(collection[deque[len(deque)-1]], item) {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+
+		if i >= size-1 {
+			result = append(result, collection[deque[0]])
+		}
+	}
+	return result
+}
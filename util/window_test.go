@@ -0,0 +1,369 @@
+package util
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestWindowMap(t *testing.T) {
+	maxOf := func(window []int) int {
+		best := window[0]
+		for _, v := range window[1:] {
+			if v > best {
+				best = v
+			}
+		}
+		return best
+	}
+
+	t.Run("computes the max of each window of 3", func(t *testing.T) {
+		input := []int{1, 3, 2, 5, 4, 1}
+		expected := []int{3, 5, 5, 5}
+		result := WindowMap(input, 3, maxOf)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("WindowMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("yields a single window when size equals length", func(t *testing.T) {
+		input := []int{4, 9, 2}
+		expected := []int{9}
+		result := WindowMap(input, 3, maxOf)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("WindowMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("does not let appends in f clobber the input", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		WindowMap(input, 2, func(window []int) int {
+			_ = append(window, 99)
+			return 0
+		})
+		expected := []int{1, 2, 3}
+		if !reflect.DeepEqual(input, expected) {
+			t.Errorf("WindowMap() modified input: got = %v, want %v", input, expected)
+		}
+	})
+
+	t.Run("returns nil when size is out of range", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		if result := WindowMap(input, 0, maxOf); result != nil {
+			t.Errorf("WindowMap() with size 0 should return nil, but got %v", result)
+		}
+		if result := WindowMap(input, 4, maxOf); result != nil {
+			t.Errorf("WindowMap() with size > length should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := WindowMap(input, 1, maxOf)
+		if result != nil {
+			t.Errorf("WindowMap() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestRollingMap(t *testing.T) {
+	sum := func(window []int) int {
+		total := 0
+		for _, item := range window {
+			total += item
+		}
+		return total
+	}
+
+	t.Run("emits full windows of size 3 every 2 elements", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		// Windows: [1 2 3], [3 4 5], [5 6 7]; the trailing [7 8] is not a full window.
+		expected := []int{6, 12, 18}
+		result := RollingMap(input, 3, 2, sum)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RollingMap() got = %v, want %v", result, expected)
+		}
+		if want := (len(input)-3)/2 + 1; len(result) != want {
+			t.Errorf("RollingMap() emitted %d results, want %d", len(result), want)
+		}
+	})
+
+	t.Run("matches WindowMap with a step of 1", func(t *testing.T) {
+		input := []int{4, 1, 7, 3, 9}
+		expected := WindowMap(input, 2, sum)
+		result := RollingMap(input, 2, 1, sum)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RollingMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("tiles the slice when step equals size", func(t *testing.T) {
+		expected := []int{3, 7}
+		result := RollingMap([]int{1, 2, 3, 4, 5}, 2, 2, sum)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RollingMap() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for invalid size or step", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		for _, params := range [][2]int{{0, 1}, {1, 0}, {4, 1}} {
+			if result := RollingMap(input, params[0], params[1], sum); result != nil {
+				t.Errorf("RollingMap(size=%d, step=%d) should return nil, but got %v", params[0], params[1], result)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := RollingMap([]int(nil), 1, 1, sum); result != nil {
+			t.Errorf("RollingMap() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestMapPairwise(t *testing.T) {
+	ratio := func(prev, cur float64, _ int) float64 { return cur / prev }
+
+	t.Run("computes ratios between consecutive values", func(t *testing.T) {
+		expected := []float64{2, 1.5, 0.5}
+		result := MapPairwise([]float64{2, 4, 6, 3}, ratio)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapPairwise() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("passes the index of the current element", func(t *testing.T) {
+		expected := []int{1, 2}
+		result := MapPairwise([]string{"a", "b", "c"}, func(_, _ string, index int) int { return index })
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapPairwise() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for fewer than two elements", func(t *testing.T) {
+		for _, input := range [][]float64{{}, {1}} {
+			result := MapPairwise(input, ratio)
+			if result == nil || len(result) != 0 {
+				t.Errorf("MapPairwise(%v) should return empty slice, but got %v", input, result)
+			}
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		if result := MapPairwise([]float64(nil), ratio); result != nil {
+			t.Errorf("MapPairwise() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestWindowClone(t *testing.T) {
+	t.Run("returns every window of the given size", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		expected := [][]int{{1, 2, 3}, {2, 3, 4}}
+		result := WindowClone(input, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("WindowClone() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("mutating one window does not affect another or the input", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		result := WindowClone(input, 2)
+		result[0][1] = 99
+		result[1] = append(result[1], 100)
+		if result[1][0] != 2 || result[2][0] != 3 {
+			t.Errorf("WindowClone() windows share elements: got %v", result)
+		}
+		if !reflect.DeepEqual(input, []int{1, 2, 3, 4}) {
+			t.Errorf("WindowClone() modified input: got %v", input)
+		}
+	})
+
+	t.Run("returns nil when size is out of range", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		if result := WindowClone(input, 0); result != nil {
+			t.Errorf("WindowClone() with size 0 should return nil, but got %v", result)
+		}
+		if result := WindowClone(input, 4); result != nil {
+			t.Errorf("WindowClone() with size > length should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		result := WindowClone(input, 1)
+		if result != nil {
+			t.Errorf("WindowClone() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestUnWindow(t *testing.T) {
+	windowsOf := func(input []int, size, step int) [][]int {
+		var windows [][]int
+		for start := 0; start+size <= len(input); start += step {
+			windows = append(windows, input[start:start+size])
+		}
+		return windows
+	}
+
+	t.Run("round-trips windows taken with step 1", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6}
+		result := UnWindow(windowsOf(input, 3, 1), 1)
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("UnWindow() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("round-trips windows taken with step 2", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7}
+		result := UnWindow(windowsOf(input, 3, 2), 2)
+		if !reflect.DeepEqual(result, input) {
+			t.Errorf("UnWindow() got = %v, want %v", result, input)
+		}
+	})
+
+	t.Run("returns the window itself for a single window", func(t *testing.T) {
+		windows := [][]int{{1, 2, 3}}
+		expected := []int{1, 2, 3}
+		result := UnWindow(windows, 2)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("UnWindow() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		expected := []int{}
+		result := UnWindow([][]int{}, 1)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("UnWindow() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns nil for nil input or invalid step", func(t *testing.T) {
+		var windows [][]int
+		if result := UnWindow(windows, 1); result != nil {
+			t.Errorf("UnWindow() on nil slice should return nil, but got %v", result)
+		}
+		if result := UnWindow([][]int{{1}}, 0); result != nil {
+			t.Errorf("UnWindow() with step 0 should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestSlidingMaxMin(t *testing.T) {
+	naive := func(input []int, size int, better func(a, b int) bool) []int {
+		return WindowMap(input, size, func(window []int) int {
+			best := window[0]
+			for _, v := range window[1:] {
+				if better(v, best) {
+					best = v
+				}
+			}
+			return best
+		})
+	}
+	greater := func(a, b int) bool { return a > b }
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("computes max and min of each window", func(t *testing.T) {
+		input := []int{1, 3, -1, -3, 5, 3, 6, 7}
+		expectedMax := []int{3, 3, 5, 5, 6, 7}
+		expectedMin := []int{-1, -3, -3, -3, 3, 3}
+		if result := SlidingMax(input, 3); !reflect.DeepEqual(result, expectedMax) {
+			t.Errorf("SlidingMax() got = %v, want %v", result, expectedMax)
+		}
+		if result := SlidingMin(input, 3); !reflect.DeepEqual(result, expectedMin) {
+			t.Errorf("SlidingMin() got = %v, want %v", result, expectedMin)
+		}
+	})
+
+	t.Run("matches a naive implementation on random data", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		for range 50 {
+			input := make([]int, 1+rng.IntN(200))
+			for i := range input {
+				input[i] = rng.IntN(20) - 10
+			}
+			size := 1 + rng.IntN(len(input))
+
+			if got, want := SlidingMax(input, size), naive(input, size, greater); !reflect.DeepEqual(got, want) {
+				t.Fatalf("SlidingMax(%v, %d) got = %v, want %v", input, size, got, want)
+			}
+			if got, want := SlidingMin(input, size), naive(input, size, less); !reflect.DeepEqual(got, want) {
+				t.Fatalf("SlidingMin(%v, %d) got = %v, want %v", input, size, got, want)
+			}
+		}
+	})
+
+	t.Run("returns nil when size is out of range", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		if result := SlidingMax(input, 0); result != nil {
+			t.Errorf("SlidingMax() with size 0 should return nil, but got %v", result)
+		}
+		if result := SlidingMin(input, 4); result != nil {
+			t.Errorf("SlidingMin() with size > length should return nil, but got %v", result)
+		}
+	})
+
+	t.Run("returns nil for nil input", func(t *testing.T) {
+		var input []int
+		if result := SlidingMax(input, 1); result != nil {
+			t.Errorf("SlidingMax() on nil slice should return nil, but got %v", result)
+		}
+		if result := SlidingMin(input, 1); result != nil {
+			t.Errorf("SlidingMin() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func TestRollingStats(t *testing.T) {
+	t.Run("computes every statistic for a window of 3", func(t *testing.T) {
+		input := []int{4, 1, 7, 3, 9}
+		expected := []WindowStat[int]{
+			{Count: 3, Sum: 12, Min: 1, Max: 7, Mean: 4},
+			{Count: 3, Sum: 11, Min: 1, Max: 7, Mean: 11.0 / 3},
+			{Count: 3, Sum: 19, Min: 3, Max: 9, Mean: 19.0 / 3},
+		}
+		result := RollingStats(input, 3)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("RollingStats() got = %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("agrees with SlidingMax and SlidingMin", func(t *testing.T) {
+		input := []float64{2.5, -1, 8, 8, 0.5, 3, -4}
+		maxima, minima := SlidingMax(input, 4), SlidingMin(input, 4)
+		for i, stat := range RollingStats(input, 4) {
+			if stat.Max != maxima[i] || stat.Min != minima[i] {
+				t.Errorf("RollingStats() window %d got min/max = %v/%v, want %v/%v",
+					i, stat.Min, stat.Max, minima[i], maxima[i])
+			}
+		}
+	})
+
+	t.Run("returns nil for invalid size or nil input", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		for _, size := range []int{0, 4} {
+			if result := RollingStats(input, size); result != nil {
+				t.Errorf("RollingStats(size=%d) should return nil, but got %v", size, result)
+			}
+		}
+		if result := RollingStats([]int(nil), 1); result != nil {
+			t.Errorf("RollingStats() on nil slice should return nil, but got %v", result)
+		}
+	})
+}
+
+func BenchmarkSlidingMax(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	input := make([]int, 100000)
+	for i := range input {
+		input[i] = rng.Int()
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		SlidingMax(input, 100)
+	}
+}
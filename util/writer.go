@@ -0,0 +1,35 @@
+// Package util provides utility functions for working with slices.
+package util
+
+import "io"
+
+// WriteChunks writes data to w in chunks of at most chunkSize bytes and returns the total
+// number of bytes written. It stops at the first write error and returns it together with
+// the bytes written so far.
+//
+// A write that accepts only part of a chunk without reporting an error is summed and the
+// remainder of the chunk is written again, so writers that make partial progress still
+// receive all of data. A write that makes no progress, or reports an impossible byte count,
+// fails with io.ErrShortWrite. It returns ErrInvalidSize if chunkSize is less than 1.
+func WriteChunks(w io.Writer, data []byte, chunkSize int) (int, error) {
+	if chunkSize < 1 {
+		return 0, ErrInvalidSize
+	}
+
+	total := 0
+	for total < len(data) {
+		chunk := data[total:min(total+chunkSize, len(data))]
+		n, err := w.Write(chunk)
+		if n < 0 || n > len(chunk) {
+			return total, io.ErrShortWrite
+		}
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n == 0 {
+			return total, io.ErrShortWrite
+		}
+	}
+	return total, nil
+}
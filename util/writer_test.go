@@ -0,0 +1,94 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// recordingWriter records the size of every write and accepts at most limit bytes per call.
+// It fails with err once failAfter bytes have been written, if err is set.
+type recordingWriter struct {
+	buf       bytes.Buffer
+	writes    []int
+	limit     int
+	failAfter int
+	err       error
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if w.err != nil && w.buf.Len() >= w.failAfter {
+		return 0, w.err
+	}
+	if w.limit > 0 && len(p) > w.limit {
+		p = p[:w.limit]
+	}
+	w.writes = append(w.writes, len(p))
+	return w.buf.Write(p)
+}
+
+func TestWriteChunks(t *testing.T) {
+	data := []byte("hello, chunked world")
+
+	t.Run("writes data in bounded chunks", func(t *testing.T) {
+		w := &recordingWriter{}
+		n, err := WriteChunks(w, data, 8)
+		if err != nil || n != len(data) {
+			t.Fatalf("WriteChunks() got = (%v, %v), want (%v, nil)", n, err, len(data))
+		}
+		if w.buf.String() != string(data) {
+			t.Errorf("WriteChunks() wrote %q, want %q", w.buf.String(), data)
+		}
+		if expected := []int{8, 8, 4}; !reflect.DeepEqual(w.writes, expected) {
+			t.Errorf("WriteChunks() write sizes got = %v, want %v", w.writes, expected)
+		}
+	})
+
+	t.Run("sums short writes and finishes each chunk", func(t *testing.T) {
+		w := &recordingWriter{limit: 3}
+		n, err := WriteChunks(w, data, 8)
+		if err != nil || n != len(data) {
+			t.Fatalf("WriteChunks() got = (%v, %v), want (%v, nil)", n, err, len(data))
+		}
+		if w.buf.String() != string(data) {
+			t.Errorf("WriteChunks() wrote %q, want %q", w.buf.String(), data)
+		}
+	})
+
+	t.Run("returns the first error mid-stream", func(t *testing.T) {
+		errDisk := errors.New("disk full")
+		w := &recordingWriter{failAfter: 10, err: errDisk}
+		n, err := WriteChunks(w, data, 5)
+		if !errors.Is(err, errDisk) || n != 10 {
+			t.Errorf("WriteChunks() got = (%v, %v), want (10, %v)", n, err, errDisk)
+		}
+	})
+
+	t.Run("fails when the writer makes no progress", func(t *testing.T) {
+		stuck := writerFunc(func(p []byte) (int, error) { return 0, nil })
+		if _, err := WriteChunks(stuck, data, 4); !errors.Is(err, io.ErrShortWrite) {
+			t.Errorf("WriteChunks() error got = %v, want %v", err, io.ErrShortWrite)
+		}
+	})
+
+	t.Run("returns error for chunk size less than 1", func(t *testing.T) {
+		if _, err := WriteChunks(&recordingWriter{}, data, 0); !errors.Is(err, ErrInvalidSize) {
+			t.Errorf("WriteChunks() error got = %v, want %v", err, ErrInvalidSize)
+		}
+	})
+
+	t.Run("writes nothing for empty data", func(t *testing.T) {
+		w := &recordingWriter{}
+		n, err := WriteChunks(w, nil, 4)
+		if n != 0 || err != nil || len(w.writes) != 0 {
+			t.Errorf("WriteChunks() on empty data got = (%v, %v) with %d writes, want (0, nil) with none", n, err, len(w.writes))
+		}
+	})
+}
+
+// writerFunc adapts a function to io.Writer.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }